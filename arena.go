@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/RenugaParamalingam/carrom/bot"
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// arenaCommand plays every pair of the bots read from a JSON file against each other and prints
+// their results, eg.
+//
+//	[{"Name": "mine", "Command": ["python3", "bot.py"]}, {"Name": "average"}]
+func arenaCommand(args []string) error {
+	flags := flag.NewFlagSet("arena", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: carrom arena [flags] bots.json")
+		flags.PrintDefaults()
+	}
+
+	games := flags.Int("games", 100, "games played by every pair of bots")
+	seed := flags.Int64("seed", 1, "seed of the first game")
+	timeout := flags.Duration("timeout", bot.DefaultTurnTimeout, "time bots have to reply to a turn")
+	maxTurns := flags.Int("max-turns", 300, "turns after which a game is won by the leader")
+	logFile := flags.String("log", "", "file to log lines sent to and received from bots to")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return fmt.Errorf("bots file is not given")
+	}
+
+	data, err := ioutil.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	a := bot.Arena{
+		Games:       *games,
+		Seed:        *seed,
+		TurnTimeout: *timeout,
+		TurnCap:     carrom.TurnCap{MaxTurns: *maxTurns, Adjudication: carrom.AdjudicateLeader},
+	}
+
+	if err := json.Unmarshal(data, &a.Entrants); err != nil {
+		return fmt.Errorf("invalid bots file: %w", err)
+	}
+
+	if *logFile != "" {
+		f, err := os.Create(*logFile)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		a.Log = f
+	}
+
+	results, err := a.Run()
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(results.Table())
+
+	return nil
+}
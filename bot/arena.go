@@ -0,0 +1,363 @@
+package bot
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	l "github.com/sirupsen/logrus"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// Entrant is a bot playing in an arena. It is an external bot if it has a command, or else a
+// built-in bot playing shots drawn from its model.
+type Entrant struct {
+	Name string
+	// Command launches the bot, eg. ["python3", "bot.py"]. It is launched for every game.
+	Command []string `json:",omitempty"`
+	// Model is shot model of a built-in bot. Built-in bots without one play average shots.
+	Model *carrom.ShotModel `json:",omitempty"`
+}
+
+// bot starts the entrant for a game.
+func (e Entrant) bot(log io.Writer) (*Bot, error) {
+	if len(e.Command) > 0 {
+		return Start(e.Name, log, e.Command[0], e.Command[1:]...)
+	}
+
+	m := carrom.ShotModelFromTurns("", nil)
+	if e.Model != nil {
+		m = *e.Model
+	}
+
+	return NewBuiltin(e.Name, m, log)
+}
+
+// Arena plays every pair of its entrants against each other, see Arena.Run.
+type Arena struct {
+	Entrants []Entrant
+	// Games is games played by every pair of entrants.
+	Games int
+	// Seed seeds the games. Game i of an arena is seeded by Seed+i.
+	Seed        int64
+	TurnTimeout time.Duration
+	// TurnCap is turn cap of the games. Games are capped at 300 turns and won by the leader
+	// if it has no cap.
+	TurnCap carrom.TurnCap
+	// Log receives every line sent to and received from bots, if it is not nil.
+	Log io.Writer
+}
+
+// defaultArenaTurnCap keeps bots which rarely pocket a coin from playing on for ever.
+var defaultArenaTurnCap = carrom.TurnCap{MaxTurns: 300, Adjudication: carrom.AdjudicateLeader}
+
+func (a Arena) validate() error {
+	if len(a.Entrants) < 2 {
+		return fmt.Errorf("arena: %d entrants, want at least 2", len(a.Entrants))
+	}
+
+	if a.Games < 1 {
+		return fmt.Errorf("arena: invalid game count %d", a.Games)
+	}
+
+	names := make(map[string]bool, len(a.Entrants))
+
+	for _, e := range a.Entrants {
+		if e.Name == "" || names[e.Name] {
+			return fmt.Errorf("arena: invalid or repeated entrant name %q", e.Name)
+		}
+
+		names[e.Name] = true
+
+		if e.Model != nil && len(e.Command) > 0 {
+			return fmt.Errorf("arena: entrant %s has both a command and a model", e.Name)
+		}
+
+		if e.Model != nil {
+			if err := e.Model.Validate(); err != nil {
+				return fmt.Errorf("arena: entrant %s: %w", e.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Record is results of an entrant in an arena. Games abandoned, because a bot exited, are not
+// counted in its games.
+type Record struct {
+	Name      string
+	Games     int
+	Wins      int
+	Draws     int
+	Losses    int
+	Abandoned int
+	// Rating is Elo rating estimated from results of all games of the arena, with entrants
+	// rated 1500 on average.
+	Rating float64
+}
+
+// WinRate returns share of games won.
+func (r Record) WinRate() float64 {
+	return rate(r.Wins, r.Games)
+}
+
+// DrawRate returns share of games drawn.
+func (r Record) DrawRate() float64 {
+	return rate(r.Draws, r.Games)
+}
+
+// Score returns points per game, a win being 1 point and a draw half a point.
+func (r Record) Score() float64 {
+	return rate(2*r.Wins+r.Draws, 2*r.Games)
+}
+
+// ScoreInterval returns the 95% confidence interval of score, as the Wilson score interval.
+func (r Record) ScoreInterval() (low, high float64) {
+	if r.Games == 0 {
+		return 0, 1
+	}
+
+	const z = 1.96
+
+	n, p := float64(r.Games), r.Score()
+	centre := (p + z*z/(2*n)) / (1 + z*z/n)
+	margin := z / (1 + z*z/n) * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+
+	return math.Max(0, centre-margin), math.Min(1, centre+margin)
+}
+
+func rate(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(count) / float64(total)
+}
+
+// ArenaResults are records of entrants in an arena ordered by rating, best first.
+type ArenaResults struct {
+	Records []Record
+}
+
+// Table returns results as a table, eg.
+//
+//	Bot      Games  Wins  Draws  Losses  Win %  Draw %  Score % (95% CI)  Rating
+//	steady   20     14    1      5       70.0   5.0     72.5 (50.9-86.9)  1580
+func (r ArenaResults) Table() string {
+	var b strings.Builder
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Bot\tGames\tWins\tDraws\tLosses\tWin %\tDraw %\tScore % (95% CI)\tRating")
+
+	for _, rec := range r.Records {
+		low, high := rec.ScoreInterval()
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.1f\t%.1f\t%.1f (%.1f-%.1f)\t%.0f\n",
+			rec.Name, rec.Games, rec.Wins, rec.Draws, rec.Losses, 100*rec.WinRate(), 100*rec.DrawRate(),
+			100*rec.Score(), 100*low, 100*high, rec.Rating)
+	}
+
+	_ = w.Flush()
+
+	return b.String()
+}
+
+// Run plays Games games between every pair of entrants, the two taking turns to break first.
+// An error is returned if the arena is invalid or a bot can't be launched.
+func (a Arena) Run() (ArenaResults, error) {
+	if err := a.validate(); err != nil {
+		return ArenaResults{}, err
+	}
+
+	tc := a.TurnCap
+	if tc == (carrom.TurnCap{}) {
+		tc = defaultArenaTurnCap
+	}
+
+	n := len(a.Entrants)
+	records := make([]Record, n)
+	// played[i][j] is games counted between entrants i and j, and scores[i][j] is points
+	// i scored in them.
+	played := make([][]float64, n)
+	scores := make([][]float64, n)
+
+	for i, e := range a.Entrants {
+		records[i].Name = e.Name
+		played[i] = make([]float64, n)
+		scores[i] = make([]float64, n)
+	}
+
+	game := 0
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			for k := 0; k < a.Games; k++ {
+				first, second := i, j
+				if k%2 == 1 {
+					first, second = j, i
+				}
+
+				outcome, err := a.play(game, tc, a.Entrants[first], a.Entrants[second])
+				if err != nil {
+					return ArenaResults{}, err
+				}
+
+				game++
+
+				count(outcome, records, played, scores, i, j)
+			}
+		}
+	}
+
+	ratings := eloRatings(played, scores)
+
+	for i := range records {
+		records[i].Rating = ratings[i]
+	}
+
+	sort.SliceStable(records, func(x, y int) bool { return records[x].Rating > records[y].Rating })
+
+	return ArenaResults{Records: records}, nil
+}
+
+// play plays a game of the arena between the entrants, first breaking. A game abandoned because
+// a bot exited is logged and its outcome returned.
+func (a Arena) play(game int, tc carrom.TurnCap, first, second Entrant) (carrom.GameOutcome, error) {
+	g := carrom.NewGame(fmt.Sprintf("arena-%d", game))
+	defer g.Close()
+
+	g.SetQuiet(true)
+
+	// bots are not shown chances, which would only slow their games.
+	if err := g.SetForecast(carrom.Forecast{}); err != nil {
+		return carrom.GameOutcome{}, err
+	}
+
+	if err := g.SetTurnCap(tc); err != nil {
+		return carrom.GameOutcome{}, err
+	}
+
+	if err := g.AddPlayers([]string{first.Name, second.Name}); err != nil {
+		return carrom.GameOutcome{}, err
+	}
+
+	if _, err := g.Start(); err != nil {
+		return carrom.GameOutcome{}, err
+	}
+
+	bots := make([]*Bot, 0, 2)
+
+	defer func() {
+		for _, b := range bots {
+			if err := b.Close(); err != nil {
+				l.WithError(err).WithField("bot", b.Name).Warnln("bot exited with error")
+			}
+		}
+	}()
+
+	for _, e := range []Entrant{first, second} {
+		b, err := e.bot(a.Log)
+		if err != nil {
+			return carrom.GameOutcome{}, err
+		}
+
+		bots = append(bots, b)
+	}
+
+	outcome, err := PlayWithOptions(g, bots, Options{TurnTimeout: a.TurnTimeout, Seed: a.Seed + int64(game)})
+	if err != nil {
+		l.WithError(err).WithField("game", g.ID).Warnln("arena game abandoned")
+	}
+
+	return outcome, nil
+}
+
+// count adds outcome of a game between entrants i and j to their records and scores.
+func count(outcome carrom.GameOutcome, records []Record, played, scores [][]float64, i, j int) {
+	if outcome.Kind == carrom.OutcomeAbandoned {
+		records[i].Abandoned++
+		records[j].Abandoned++
+
+		return
+	}
+
+	records[i].Games++
+	records[j].Games++
+	played[i][j]++
+	played[j][i]++
+
+	switch outcome.Winner {
+	case "":
+		records[i].Draws++
+		records[j].Draws++
+		scores[i][j] += 0.5
+		scores[j][i] += 0.5
+	case records[i].Name:
+		records[i].Wins++
+		records[j].Losses++
+		scores[i][j]++
+	default:
+		records[j].Wins++
+		records[i].Losses++
+		scores[j][i]++
+	}
+}
+
+// eloRatings returns Elo ratings of entrants which best explain their scores against each
+// other by the Bradley-Terry model, averaging 1500. A drawn game is added between every pair
+// of entrants, so entrants who won or lost all their games have a finite rating.
+func eloRatings(played, scores [][]float64) []float64 {
+	n := len(played)
+	strength := make([]float64, n)
+
+	for i := range strength {
+		strength[i] = 1
+	}
+
+	for iteration := 0; iteration < 1000; iteration++ {
+		next := make([]float64, n)
+		logSum := 0.0
+
+		for i := range strength {
+			won, expected := 0.0, 0.0
+
+			for j := range strength {
+				if i == j {
+					continue
+				}
+
+				won += scores[i][j] + 0.5
+				expected += (played[i][j] + 1) / (strength[i] + strength[j])
+			}
+
+			next[i] = won / expected
+			logSum += math.Log(next[i])
+		}
+
+		change := 0.0
+		mean := math.Exp(logSum / float64(n))
+
+		for i := range next {
+			next[i] /= mean
+			change = math.Max(change, math.Abs(next[i]-strength[i]))
+		}
+
+		strength = next
+
+		if change < 1e-9 {
+			break
+		}
+	}
+
+	ratings := make([]float64, n)
+	for i, s := range strength {
+		ratings[i] = 1500 + 400*math.Log10(s)
+	}
+
+	return ratings
+}
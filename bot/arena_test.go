@@ -0,0 +1,98 @@
+package bot_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/bot"
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestArena(t *testing.T) {
+	sharp := carrom.ShotModel{1, 0, 0, 0, 0, 0}
+	blunt := carrom.ShotModel{0, 0, 0, 0, 0, 1}
+
+	a := bot.Arena{
+		Entrants: []bot.Entrant{{Name: "blunt", Model: &blunt}, {Name: "average"}, {Name: "sharp", Model: &sharp}},
+		Games:    6,
+		Seed:     7,
+	}
+
+	results, err := a.Run()
+	if err != nil {
+		t.Fatalf("Run()= %v, want= nil", err)
+	}
+
+	if len(results.Records) != 3 {
+		t.Fatalf("Run()= %d records, want= 3", len(results.Records))
+	}
+
+	best, worst := results.Records[0], results.Records[2]
+
+	if best.Name != "sharp" || best.Games != 12 || best.WinRate() <= 0.5 || best.Rating <= 1500 {
+		t.Errorf("best record= %+v, want= sharp winning most of 12 games rated over 1500", best)
+	}
+
+	if worst.Name != "blunt" || worst.Wins != 0 || worst.Rating >= 1500 {
+		t.Errorf("worst record= %+v, want= blunt winning no game rated under 1500", worst)
+	}
+
+	if low, high := best.ScoreInterval(); low <= 0.5 || high <= best.Score() || high > 1 {
+		t.Errorf("ScoreInterval() of %+v= %g-%g, want= over 0.5 around its score", best, low, high)
+	}
+
+	total := 0.0
+	for _, r := range results.Records {
+		total += r.Rating
+	}
+
+	if total/3 < 1499.9 || total/3 > 1500.1 {
+		t.Errorf("average rating= %g, want= 1500", total/3)
+	}
+
+	table := results.Table()
+	for _, want := range []string{"Score % (95% CI)", "sharp ", "blunt "} {
+		if !strings.Contains(table, want) {
+			t.Errorf("Table() has no %q:\n%s", want, table)
+		}
+	}
+
+	again, err := a.Run()
+	if err != nil || !reflect.DeepEqual(again, results) {
+		t.Errorf("Run() again= %+v, err: %v, want= %+v", again, err, results)
+	}
+}
+
+func TestArenaEvenEntrants(t *testing.T) {
+	a := bot.Arena{Entrants: []bot.Entrant{{Name: "p1"}, {Name: "p2"}}, Games: 2}
+
+	results, err := a.Run()
+	if err != nil {
+		t.Fatalf("Run()= %v, want= nil", err)
+	}
+
+	for _, r := range results.Records {
+		if r.Games != 2 || r.Wins+r.Draws+r.Losses != 2 {
+			t.Errorf("record= %+v, want= 2 games", r)
+		}
+	}
+}
+
+func TestInvalidArena(t *testing.T) {
+	invalid := carrom.ShotModel{}
+
+	arenas := []bot.Arena{
+		{Entrants: []bot.Entrant{{Name: "p1"}}, Games: 1},
+		{Entrants: []bot.Entrant{{Name: "p1"}, {Name: "p2"}}},
+		{Entrants: []bot.Entrant{{Name: "p1"}, {Name: "p1"}}, Games: 1},
+		{Entrants: []bot.Entrant{{Name: "p1"}, {Name: "p2", Model: &invalid}}, Games: 1},
+		{Entrants: []bot.Entrant{{Name: "p1"}, {Name: "p2", Command: []string{"/nonexistent/bot"}}}, Games: 1},
+	}
+
+	for _, a := range arenas {
+		if _, err := a.Run(); err == nil {
+			t.Errorf("Run() of %+v= nil, want= error", a)
+		}
+	}
+}
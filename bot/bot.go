@@ -0,0 +1,235 @@
+// Package bot lets strategy programs written in any language play carrom games. A bot is
+// a subprocess talking a line based JSON protocol on its stdin and stdout, see Message and Reply.
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	l "github.com/sirupsen/logrus"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// ProtocolVersion is sent to bots in the start message.
+const ProtocolVersion = 1
+
+// Message types sent to bots.
+const (
+	// StartMessage tells bot the player it plays for, the game at start and its seed.
+	StartMessage = "start"
+	// TurnMessage asks bot for the shot of a turn. Bot replies to it with a Reply.
+	TurnMessage = "turn"
+	// TimeoutMessage tells bot it didn't reply to the turn in time.
+	TimeoutMessage = "timeout"
+	// IllegalMessage tells bot its reply to the turn was not a valid shot.
+	IllegalMessage = "illegal"
+	// EndMessage tells bot outcome of the game. Bot should exit after it.
+	EndMessage = "end"
+)
+
+// Message is a line sent to bots, eg.
+//
+//	{"type":"turn","turn":3,"player":"p1","state":{"State":"in progress","Turn":2,...}}
+type Message struct {
+	Type     string               `json:"type"`
+	Protocol int                  `json:"protocol,omitempty"`
+	Turn     int                  `json:"turn,omitempty"`
+	Player   string               `json:"player,omitempty"`
+	Seed     int64                `json:"seed,omitempty"`
+	State    *carrom.GameSnapshot `json:"state,omitempty"`
+	Error    string               `json:"error,omitempty"`
+	Outcome  *carrom.GameOutcome  `json:"outcome,omitempty"`
+}
+
+// Reply is a line bots reply to turn messages with, giving the turn it is for and the shot
+// played in it, eg.
+//
+//	{"turn":3,"shot":{"Pocketed":{"Black":1}}}
+type Reply struct {
+	Turn int         `json:"turn"`
+	Shot carrom.Shot `json:"shot"`
+}
+
+// ErrExited is returned when a bot stops replying, eg. because its process exited.
+var ErrExited = errors.New("bot exited")
+
+// Bot is a connection to a bot playing for a player.
+type Bot struct {
+	Name string
+
+	w     io.Writer
+	lines chan string
+	// log receives every line sent and received, prefixed by name of the bot.
+	log io.Writer
+
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// logMu keeps lines of bots sharing a log from mixing.
+var logMu sync.Mutex
+
+// New returns bot playing for the player named, reading its replies from r and writing
+// messages to w. Lines sent and received are written to log, if it is not nil.
+func New(name string, r io.Reader, w io.Writer, log io.Writer) *Bot {
+	b := &Bot{
+		Name:  name,
+		w:     w,
+		lines: make(chan string),
+		log:   log,
+		done:  make(chan struct{}),
+	}
+
+	go b.read(r)
+
+	return b
+}
+
+// Start launches the command of a bot playing for the player named, eg. "python3 bot.py".
+// Lines bot writes to stderr are logged.
+func Start(name string, log io.Writer, command string, args ...string) (*Bot, error) {
+	cmd := exec.Command(command, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start bot %s: %w", name, err)
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			l.WithField("bot", name).Infoln(scanner.Text())
+		}
+	}()
+
+	b := New(name, stdout, stdin, log)
+	b.cmd = cmd
+
+	return b, nil
+}
+
+// read sends lines of r to lines until r ends.
+func (b *Bot) read(r io.Reader) {
+	defer close(b.lines)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		select {
+		case b.lines <- scanner.Text():
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// send writes m to bot as a line.
+func (b *Bot) send(m Message) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	b.logLine(">", data)
+
+	if _, err := b.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("bot %s: %w: %v", b.Name, ErrExited, err)
+	}
+
+	return nil
+}
+
+// reply returns reply of bot to the turn, skipping replies to other turns, eg. late replies
+// to turns timed out. ok is false if bot didn't reply in time. An error is returned for
+// lines which are not a reply.
+func (b *Bot) reply(turn int, timeout time.Duration) (r Reply, ok bool, err error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case line, open := <-b.lines:
+			if !open {
+				return Reply{}, false, fmt.Errorf("bot %s: %w", b.Name, ErrExited)
+			}
+
+			b.logLine("<", []byte(line))
+
+			if err := json.Unmarshal([]byte(line), &r); err != nil {
+				return Reply{}, true, fmt.Errorf("invalid reply %q: %v", line, err)
+			}
+
+			if r.Turn == turn {
+				return r, true, nil
+			}
+
+			l.WithFields(l.Fields{"bot": b.Name, "turn": turn, "replyTurn": r.Turn}).Warnln("reply to another turn ignored")
+		case <-timer.C:
+			return Reply{}, false, nil
+		}
+	}
+}
+
+func (b *Bot) logLine(direction string, line []byte) {
+	if b.log == nil {
+		return
+	}
+
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	fmt.Fprintf(b.log, "%s %s %s\n", b.Name, direction, line)
+}
+
+// Close stops reading from bot and waits for its process, if it was started by Start, to exit.
+// The process is killed if it doesn't exit within a second of its stdin being closed.
+func (b *Bot) Close() error {
+	select {
+	case <-b.done:
+		return nil
+	default:
+		close(b.done)
+	}
+
+	if c, ok := b.w.(io.Closer); ok {
+		_ = c.Close()
+	}
+
+	if b.cmd == nil {
+		return nil
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- b.cmd.Wait() }()
+
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(time.Second):
+		_ = b.cmd.Process.Kill()
+
+		return <-exited
+	}
+}
@@ -0,0 +1,351 @@
+package bot_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RenugaParamalingam/carrom/bot"
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// fakeBot replies to turn messages with replies in order, an empty reply being no reply,
+// and exits after the last one. It records the messages it receives.
+type fakeBot struct {
+	mu       sync.Mutex
+	messages []bot.Message
+}
+
+func (f *fakeBot) received() []bot.Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]bot.Message(nil), f.messages...)
+}
+
+func (f *fakeBot) types() []string {
+	var types []string
+	for _, m := range f.received() {
+		types = append(types, m.Type)
+	}
+
+	return types
+}
+
+func newFakeBot(t *testing.T, name string, log io.Writer, replies ...string) (*bot.Bot, *fakeBot) {
+	t.Helper()
+
+	engineIn, botOut := io.Pipe()
+	botIn, engineOut := io.Pipe()
+	f := &fakeBot{}
+
+	go func() {
+		defer botOut.Close()
+
+		scanner := bufio.NewScanner(botIn)
+		for scanner.Scan() {
+			var m bot.Message
+			if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+				t.Errorf("bot %s received %q: %v", name, scanner.Text(), err)
+
+				return
+			}
+
+			f.mu.Lock()
+			f.messages = append(f.messages, m)
+			f.mu.Unlock()
+
+			if m.Type != bot.TurnMessage {
+				continue
+			}
+
+			if len(replies) == 0 {
+				return
+			}
+
+			reply := strings.ReplaceAll(replies[0], "TURN", fmt.Sprint(m.Turn))
+			replies = replies[1:]
+
+			if reply != "" {
+				fmt.Fprintln(botOut, reply)
+			}
+		}
+	}()
+
+	return bot.New(name, engineIn, engineOut, log), f
+}
+
+func startGame(t *testing.T, names ...string) *carrom.Game {
+	t.Helper()
+
+	g := carrom.NewGame("")
+	if err := g.AddPlayers(names); err != nil {
+		t.Fatalf("AddPlayers(%v)= %v, want= nil", names, err)
+	}
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	return g
+}
+
+const (
+	red  = `{"turn":TURN,"shot":{"Pocketed":{"IsRedPocketed":true}}}`
+	miss = `{"turn":TURN,"shot":{}}`
+	two  = `{"turn":TURN,"shot":{"Pocketed":{"Black":2}}}`
+)
+
+func TestPlay(t *testing.T) {
+	var log bytes.Buffer
+
+	b1, f1 := newFakeBot(t, "p1", &log, red, two)
+	b2, f2 := newFakeBot(t, "p2", &log, miss)
+
+	defer b1.Close()
+	defer b2.Close()
+
+	g := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	outcome, err := bot.Play(g, []*bot.Bot{b1, b2}, time.Second)
+	if err != nil {
+		t.Fatalf("Play()= %v, want= nil", err)
+	}
+
+	if outcome.Kind != carrom.OutcomeWon || outcome.Winner != "p1" {
+		t.Errorf("Play()= %v won by %q, want= won by p1", outcome.Kind, outcome.Winner)
+	}
+
+	b1.Close()
+	b2.Close()
+
+	want := []string{bot.StartMessage, bot.TurnMessage, bot.TurnMessage, bot.EndMessage}
+	if got := f1.types(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("p1 received %v, want= %v", got, want)
+	}
+
+	want = []string{bot.StartMessage, bot.TurnMessage, bot.EndMessage}
+	if got := f2.types(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("p2 received %v, want= %v", got, want)
+	}
+
+	start := f1.received()[0]
+	if start.Protocol != bot.ProtocolVersion || start.Player != "p1" || start.State == nil {
+		t.Errorf("start message= %+v, want= protocol %d for p1 with state", start, bot.ProtocolVersion)
+	}
+
+	turn := f2.received()[1]
+	if turn.Turn != 2 || turn.State == nil || turn.State.Players[0].Points != 3 {
+		t.Errorf("turn message= %+v, want= turn 2 with p1 on 3 points", turn)
+	}
+
+	end := f2.received()[2]
+	if end.Outcome == nil || end.Outcome.Winner != "p1" {
+		t.Errorf("end message= %+v, want= outcome won by p1", end)
+	}
+
+	for _, line := range []string{`p1 > {"type":"start"`, `p1 < {"turn":1,`, `p2 > {"type":"end"`} {
+		if !strings.Contains(log.String(), line) {
+			t.Errorf("log has no line %q:\n%s", line, log.String())
+		}
+	}
+}
+
+func TestPlayWithOptions(t *testing.T) {
+	b1, f1 := newFakeBot(t, "p1", nil, red, two)
+	b2, _ := newFakeBot(t, "p2", nil, miss)
+
+	defer b1.Close()
+	defer b2.Close()
+
+	g := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	outcome, err := bot.PlayWithOptions(g, []*bot.Bot{b1, b2}, bot.Options{TurnTimeout: time.Second, Seed: 42})
+	if err != nil || outcome.Winner != "p1" {
+		t.Fatalf("PlayWithOptions()= won by %q, err: %v, want= won by p1", outcome.Winner, err)
+	}
+
+	if start := f1.received()[0]; start.Type != bot.StartMessage || start.Seed != 42 {
+		t.Errorf("start message= %+v, want= seed 42", start)
+	}
+}
+
+func TestPlayMissesTurns(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		want  string
+	}{
+		{"no reply", "", bot.TimeoutMessage},
+		{"invalid json", `{"turn":`, bot.IllegalMessage},
+		{"contradictory shot", `{"turn":TURN,"shot":{"Pocketed":{"Black":-1}}}`, bot.IllegalMessage},
+		{"wrong seat", `{"turn":TURN,"shot":{"StrikerSide":"north"}}`, bot.IllegalMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b1, f1 := newFakeBot(t, "p1", nil, tt.reply, red, two)
+			b2, _ := newFakeBot(t, "p2", nil, miss, miss)
+
+			defer b1.Close()
+			defer b2.Close()
+
+			g := startGame(t, "p1", "p2")
+			defer g.Close()
+
+			outcome, err := bot.Play(g, []*bot.Bot{b1, b2}, 100*time.Millisecond)
+			if err != nil {
+				t.Fatalf("Play()= %v, want= nil", err)
+			}
+
+			if outcome.Winner != "p1" {
+				t.Errorf("Play() won by %q, want= p1", outcome.Winner)
+			}
+
+			messages := f1.received()
+			if len(messages) < 3 || messages[2].Type != tt.want || messages[2].Turn != 1 {
+				t.Fatalf("p1 received %v, want= %s for turn 1", f1.types(), tt.want)
+			}
+
+			if tt.want == bot.IllegalMessage && messages[2].Error == "" {
+				t.Errorf("illegal message has no error")
+			}
+
+			if turns := g.Turns(); turns[0].Input.StrikeCode != 5 {
+				t.Errorf("turn 1= %v, want= a miss", turns[0].Input)
+			}
+		})
+	}
+}
+
+func TestPlayIgnoresRepliesToOtherTurns(t *testing.T) {
+	b1, _ := newFakeBot(t, "p1", nil, `{"turn":7,"shot":{}}`+"\n"+red, two)
+	b2, _ := newFakeBot(t, "p2", nil, miss)
+
+	defer b1.Close()
+	defer b2.Close()
+
+	g := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	if _, err := bot.Play(g, []*bot.Bot{b1, b2}, time.Second); err != nil {
+		t.Fatalf("Play()= %v, want= nil", err)
+	}
+
+	if turns := g.Turns(); turns[0].Input.StrikeCode != 2 {
+		t.Errorf("turn 1= %v, want= red strike", turns[0].Input)
+	}
+}
+
+func TestPlayBotExits(t *testing.T) {
+	b1, _ := newFakeBot(t, "p1", nil, red)
+	b2, _ := newFakeBot(t, "p2", nil)
+
+	defer b1.Close()
+	defer b2.Close()
+
+	g := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	outcome, err := bot.Play(g, []*bot.Bot{b1, b2}, time.Second)
+	if !errors.Is(err, bot.ErrExited) {
+		t.Errorf("Play()= %v, want= %v", err, bot.ErrExited)
+	}
+
+	if outcome.Kind != carrom.OutcomeAbandoned {
+		t.Errorf("Play()= %v, want= %v", outcome.Kind, carrom.OutcomeAbandoned)
+	}
+}
+
+func TestPlayWithoutBot(t *testing.T) {
+	b1, _ := newFakeBot(t, "p1", nil)
+	defer b1.Close()
+
+	g := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	if _, err := bot.Play(g, []*bot.Bot{b1}, time.Second); err == nil {
+		t.Errorf("Play() without bot of p2= nil, want= error")
+	}
+
+	if s := g.State(); s != carrom.InProgress {
+		t.Errorf("State()= %v, want= %v", s, carrom.InProgress)
+	}
+}
+
+// TestHelperBot is not a test, it is run as a bot process by TestStart. It pockets red and
+// then two blacks.
+func TestHelperBot(t *testing.T) {
+	if os.Getenv("CARROM_HELPER_BOT") != "1" {
+		return
+	}
+
+	replies := []string{red, two}
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for scanner.Scan() {
+		var m bot.Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			os.Exit(2)
+		}
+
+		switch {
+		case m.Type == bot.EndMessage:
+			os.Exit(0)
+		case m.Type == bot.TurnMessage && len(replies) > 0:
+			fmt.Fprintln(os.Stderr, "playing turn", m.Turn)
+			fmt.Println(strings.ReplaceAll(replies[0], "TURN", fmt.Sprint(m.Turn)))
+			replies = replies[1:]
+		}
+	}
+
+	os.Exit(0)
+}
+
+func TestStart(t *testing.T) {
+	if _, err := bot.Start("p1", nil, "/nonexistent/bot"); err == nil {
+		t.Errorf("Start() of missing command= nil, want= error")
+	}
+
+	// the race detector keeps processes a second after they exit unless told otherwise.
+	for key, value := range map[string]string{"CARROM_HELPER_BOT": "1", "GORACE": "atexit_sleep_ms=0"} {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Unsetenv(key)
+	}
+
+	b1, err := bot.Start("p1", nil, os.Args[0], "-test.run=TestHelperBot")
+	if err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	b2, _ := newFakeBot(t, "p2", nil, miss)
+	defer b2.Close()
+
+	g := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	outcome, err := bot.Play(g, []*bot.Bot{b1, b2}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("Play()= %v, want= nil", err)
+	}
+
+	if outcome.Winner != "p1" {
+		t.Errorf("Play() won by %q, want= p1", outcome.Winner)
+	}
+
+	if err := b1.Close(); err != nil {
+		t.Errorf("Close()= %v, want= nil", err)
+	}
+}
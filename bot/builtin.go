@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+
+	l "github.com/sirupsen/logrus"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// NewBuiltin returns bot playing for the player named which runs in process and plays shots
+// drawn from the shot model, see carrom.ShotModel.Sample. Its shots are seeded by the seed of
+// the game and its name, so it plays the same shots in a game played again. It talks the same
+// protocol as bots started by Start.
+func NewBuiltin(name string, m carrom.ShotModel, log io.Writer) (*Bot, error) {
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("bot %s: %w", name, err)
+	}
+
+	engineIn, botOut := io.Pipe()
+	botIn, engineOut := io.Pipe()
+
+	go playBuiltin(name, m, botIn, botOut)
+
+	return New(name, engineIn, engineOut, log), nil
+}
+
+// playBuiltin replies to turn messages read from r with shots drawn from m until the game ends
+// or r is closed.
+func playBuiltin(name string, m carrom.ShotModel, r *io.PipeReader, w *io.PipeWriter) {
+	defer w.Close()
+	defer r.Close()
+
+	var random *rand.Rand
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			l.WithError(err).WithField("bot", name).Errorln("invalid message")
+
+			return
+		}
+
+		switch msg.Type {
+		case StartMessage:
+			h := fnv.New64a()
+			_, _ = h.Write([]byte(name))
+			random = rand.New(rand.NewSource(msg.Seed ^ int64(h.Sum64())))
+		case TurnMessage:
+			if random == nil || msg.State == nil {
+				l.WithField("bot", name).Errorln("turn before start of game")
+
+				return
+			}
+
+			c := m.Sample(random, msg.State.Coins)
+
+			data, err := json.Marshal(Reply{Turn: msg.Turn, Shot: c.Shot()})
+			if err != nil {
+				return
+			}
+
+			if _, err := w.Write(append(data, '\n')); err != nil {
+				return
+			}
+		case EndMessage:
+			return
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	l "github.com/sirupsen/logrus"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// DefaultTurnTimeout is how long bots have to reply to a turn if Play is given no timeout.
+const DefaultTurnTimeout = 5 * time.Second
+
+// Options are options of a game played by bots, see PlayWithOptions.
+type Options struct {
+	// TurnTimeout is how long bots have to reply to a turn, DefaultTurnTimeout if it is 0.
+	TurnTimeout time.Duration
+	// Seed is sent to bots at start, so bots playing random shots can play a game again.
+	Seed int64
+}
+
+// miss is played for bots which don't reply in time or reply with an illegal shot.
+var miss = carrom.Input{StrikeCode: 5}
+
+// Play plays the game started by g.Start until it is over, asking bot of the current player
+// for the shot of every turn. Bots are named by the players they play for. A bot which doesn't
+// reply within turnTimeout, or replies with a shot which can't be played, is told so and misses
+// the turn. The game is abandoned and an error is returned if a bot exits before it is over.
+// Outcome of the game is sent to all bots at the end.
+func Play(g *carrom.Game, bots []*Bot, turnTimeout time.Duration) (carrom.GameOutcome, error) {
+	return PlayWithOptions(g, bots, Options{TurnTimeout: turnTimeout})
+}
+
+// PlayWithOptions plays the game like Play, with the turn timeout and seed of opts.
+func PlayWithOptions(g *carrom.Game, bots []*Bot, opts Options) (carrom.GameOutcome, error) {
+	if opts.TurnTimeout <= 0 {
+		opts.TurnTimeout = DefaultTurnTimeout
+	}
+
+	s := g.Snapshot()
+	if s.State != carrom.InProgress {
+		return carrom.GameOutcome{}, fmt.Errorf("play: game is %s", s.State)
+	}
+
+	byName := make(map[string]*Bot, len(bots))
+	for _, b := range bots {
+		byName[b.Name] = b
+	}
+
+	for _, p := range s.Players {
+		if byName[p.PlayerName] == nil {
+			return carrom.GameOutcome{}, fmt.Errorf("play: player %s has no bot", p.PlayerName)
+		}
+	}
+
+	abandon := func(err error) (carrom.GameOutcome, error) {
+		if abandonErr := g.Abandon(); abandonErr != nil {
+			l.WithError(abandonErr).Errorln("failed to abandon game of bots")
+		}
+
+		return g.Outcome(), err
+	}
+
+	for _, p := range s.Players {
+		if err := byName[p.PlayerName].send(Message{Type: StartMessage, Protocol: ProtocolVersion, Player: p.PlayerName, Seed: opts.Seed, State: &s}); err != nil {
+			return abandon(err)
+		}
+	}
+
+	for s.State == carrom.InProgress {
+		if err := turn(g, byName[s.CurrentPlayer], s, opts.TurnTimeout); err != nil {
+			return abandon(err)
+		}
+
+		s = g.Snapshot()
+	}
+
+	outcome := g.Outcome()
+
+	for _, b := range byName {
+		if err := b.send(Message{Type: EndMessage, State: &s, Outcome: &outcome}); err != nil {
+			l.WithError(err).WithField("bot", b.Name).Warnln("failed to send end of game")
+		}
+	}
+
+	return outcome, nil
+}
+
+// turn asks bot for the shot of the turn after snapshot s and plays it.
+func turn(g *carrom.Game, b *Bot, s carrom.GameSnapshot, timeout time.Duration) error {
+	number := s.Turn + 1
+
+	if err := b.send(Message{Type: TurnMessage, Turn: number, Player: b.Name, State: &s}); err != nil {
+		return err
+	}
+
+	r, replied, err := b.reply(number, timeout)
+
+	switch {
+	case !replied && err != nil:
+		return err
+	case !replied:
+		l.WithFields(l.Fields{"bot": b.Name, "turn": number}).Warnln("bot timed out")
+
+		return b.missTurn(g, Message{Type: TimeoutMessage, Turn: number})
+	case err != nil:
+		return b.missTurn(g, Message{Type: IllegalMessage, Turn: number, Error: err.Error()})
+	}
+
+	if _, err := g.PlayShot(r.Shot); err != nil {
+		return b.missTurn(g, Message{Type: IllegalMessage, Turn: number, Error: err.Error()})
+	}
+
+	return nil
+}
+
+// missTurn tells bot why its turn is missed and plays a miss for it.
+func (b *Bot) missTurn(g *carrom.Game, m Message) error {
+	if m.Error != "" {
+		l.WithFields(l.Fields{"bot": b.Name, "turn": m.Turn}).Warnln("illegal reply:", m.Error)
+	}
+
+	if err := b.send(m); err != nil {
+		return err
+	}
+
+	if _, err := g.PlayTurn(miss); err != nil {
+		return fmt.Errorf("bot %s missing turn %d: %w", b.Name, m.Turn, err)
+	}
+
+	return nil
+}
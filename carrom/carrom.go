@@ -2,6 +2,8 @@ package carrom
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	l "github.com/sirupsen/logrus"
 )
@@ -15,7 +17,8 @@ type Input struct {
 }
 
 // StrikeCodeInput is a channel to flow in the strike type and coins for the game.
-// When games ends, channel will be closed internally. Don't close channel by yourself.
+// When game ends, input is no longer applied and an error is logged for it.
+// Don't close channel by yourself.
 // Input code and it's strike name are,
 // 0 - strike
 // 1 - multi strike
@@ -25,65 +28,160 @@ type Input struct {
 // 5 - no coin pocketed
 var StrikeCodeInput chan Input
 
+// defaultGame is the game played through the package level functions.
+// It is replaced by a new game for every AddPlayersToGame and NewBoard.
+var (
+	defaultMu   sync.Mutex
+	defaultGame = NewGame("")
+)
+
+func getDefaultGame() *Game {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	return defaultGame
+}
+
 // NewBoard resets or prepare pre-requesties for game.
 // A channel is returned to feed the input for game.
+// If the game of the last board is not over, it is abandoned.
 func NewBoard() chan Input {
-	CoinsOnBoard = &Coins{
-		Red:   1,
-		White: 9,
-		Black: 9,
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if defaultGame.State() != Setup {
+		names := make([]string, 0)
+		for _, p := range defaultGame.Players() {
+			names = append(names, p.PlayerName)
+		}
+
+		defaultGame.Close()
+		defaultGame = NewGame("")
+
+		if err := defaultGame.AddPlayers(names); err != nil {
+			l.WithError(err).Errorln("failed to add players to new board")
+		}
+	}
+
+	input, err := defaultGame.Start()
+	if err != nil {
+		l.WithError(err).Errorln("failed to start new board")
 	}
 
-	playerIDForTurn = 0
+	defaultGame.mu.Lock()
+	CoinsOnBoard = defaultGame.coins
+	defaultGame.mu.Unlock()
 
-	StrikeCodeInput = make(chan Input, 1)
-	go mapInputToStrike()
+	StrikeCodeInput = input
 
 	return StrikeCodeInput
 }
 
 // IsGameOver returns true if any player won or match ended in draw.
-func IsGameOver() (gameEnd bool) {
-	playersScore := make(map[string]int, 0)
+func IsGameOver() bool {
+	return getDefaultGame().IsOver()
+}
 
-	for _, p := range playersOnBoard {
-		playersScore[p.PlayerName] = p.Points
-	}
+// Outcome returns outcome of the game started by NewBoard.
+func Outcome() GameOutcome {
+	return getDefaultGame().Outcome()
+}
 
-	highestScorer := gethighestScore(playersOnBoard...)
+// GameState returns state of the game started by NewBoard.
+func GameState() State {
+	return getDefaultGame().State()
+}
 
-	var winner *Player
+// PlayTurn applies input to the player whose turn it is and waits until it is applied.
+// Turn will be passed to next player only if current player successfully completes
+// his turn by providing valid input. Otherwise an error is returned and the same
+// player has to play again.
+func PlayTurn(c Input) (TurnResult, error) {
+	return getDefaultGame().PlayTurn(c)
+}
 
-	for _, p := range playersOnBoard {
-		if highestScorer.Points-p.Points >= 3 && highestScorer.Points >= 5 {
-			winner = highestScorer
-			break
-		}
+// Undo takes back the last turn of the game started by NewBoard.
+func Undo() error {
+	return getDefaultGame().Undo()
+}
+
+// A TurnResult describes what a single turn did to the player who played it.
+// Timeout is set for turns recorded by the shot clock and Eliminated for turns
+// which knocked the player out of the game. Strikes are set for compound shots,
+// see PlayShot, in the order they were applied and Input is the first of them.
+// Foul is set for fouls declared by the umpire, which have no input, see DeclareFoul.
+type TurnResult struct {
+	Turn        int
+	PlayerName  string
+	Input       Input
+	Strikes     []Input `json:",omitempty"`
+	PointsDelta int
+	Fouled      bool
+	Timeout     bool
+	Eliminated  bool
+	Foul        FoulType `json:",omitempty"`
+	// Shot is the shot played by PlayShot.
+	Shot *Shot `json:",omitempty"`
+}
+
+func printScore(standings []Standing) {
+	fmt.Print(scoreBoard(standings, nil))
+}
+
+// scoreBoard returns score board of the standings, with handicaps of players if any of them has
+// one and chances of players to win if they are given.
+func scoreBoard(standings []Standing, chances map[string]Chances) string {
+	withHandicap := false
+
+	for _, p := range standings {
+		withHandicap = withHandicap || p.Handicap != Handicap{}
+	}
+
+	header := "| Player Name | Score |"
+	if withHandicap {
+		header += fmt.Sprintf(" %-36v |", "Handicap")
 	}
 
-	if winner != nil {
-		l.Printf("\n Player named %q won the game by scoring %v points. \n", winner.PlayerName, winner.Points)
-	} else if isBoardEmpty() {
-		l.Println("\n Coins exhausted and no players won. Game ends in draw.")
+	if chances != nil {
+		header += " Win % |"
 	}
 
-	gameEnd = winner != nil || isBoardEmpty()
+	var b strings.Builder
+
+	line := strings.Repeat("-", len(header))
+	fmt.Fprintf(&b, "\n Score board \n %s  \n %s \n %s \n", line, header, line)
+
+	for _, p := range standings {
+		fmt.Fprintf(&b, " | %-11v | %-5v |", p.PlayerName, p.Points)
 
-	// stop receiving input as game is end.
-	if gameEnd {
-		printScore(playersOnBoard)
-		close(StrikeCodeInput)
+		if withHandicap {
+			fmt.Fprintf(&b, " %-36v |", p.Handicap)
+		}
+
+		if chances != nil {
+			fmt.Fprintf(&b, " %5.1f |", chances[p.PlayerName].Win*100)
+		}
+
+		b.WriteString(" \n")
 	}
 
-	return gameEnd
+	return b.String()
 }
 
-func printScore(players []*Player) {
-	fmt.Printf("\n Score board \n -----------------------  \n | Player Name | Score | \n ----------------------- \n")
+// ScoreBoard returns score board of the game, as printed once it is over, with chances of
+// players to win if the game has a forecast, see SetForecast.
+func (g *Game) ScoreBoard() string {
+	g.mu.Lock()
 
-	for _, p := range players {
-		fmt.Printf(" | %-11v | %-5v | \n", p.PlayerName, p.Points)
+	standings := g.outcome.Standings
+	if !g.state.IsOver() {
+		standings = g.standings(nil)
 	}
+
+	job := g.chancesJob()
+	g.mu.Unlock()
+
+	return scoreBoard(standings, job.run())
 }
 
 func gethighestScore(players ...*Player) *Player {
@@ -97,39 +195,3 @@ func gethighestScore(players ...*Player) *Player {
 
 	return max
 }
-
-func isBoardEmpty() bool {
-	return CoinsOnBoard.Red == 0 && CoinsOnBoard.Black == 0 && CoinsOnBoard.White == 0
-}
-
-func mapInputToStrike() {
-	var err error
-	p := playersOnBoard[0]
-
-	for c := range StrikeCodeInput {
-		// turn will be passed to next player only if current player
-		// successfuly completes his turn by providing valid input.
-		if err == nil {
-			p = passPlayer()
-		}
-
-		l.WithField("input", c).Infoln("input received")
-
-		switch c.StrikeCode {
-		case 0:
-			err = p.Strike(c.CoinsPocketedCount)
-		case 1:
-			err = p.MultiStrike(c.CoinsPocketedCount)
-		case 2:
-			err = p.RedStrike()
-		case 3:
-			p.StrikerStrike()
-		case 4:
-			err = p.Defunct(c.CoinsPocketedCount)
-		case 5:
-			p.NoPocket()
-		default:
-			l.WithField("strikeCode", c.StrikeCode).Errorln("invalid strike code")
-		}
-	}
-}
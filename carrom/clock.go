@@ -0,0 +1,427 @@
+package carrom
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is source of time for shot and game clocks.
+// Real time is used by default. ManualClock can be used to control time in tests.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending call created by Clock.AfterFunc.
+type Timer interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+// ManualClock is a Clock which moves only when Advance is called.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	clock *ManualClock
+	at    time.Time
+	f     func()
+}
+
+// NewManualClock returns a clock which is set to start.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns current time of clock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc calls f once clock is advanced by at least d.
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &manualTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+// Advance moves clock forward by d and calls functions of the timers
+// which expire in that period in the order of their expiry.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+
+		sort.SliceStable(c.timers, func(i, j int) bool { return c.timers[i].at.Before(c.timers[j].at) })
+
+		if len(c.timers) == 0 || c.timers[0].at.After(end) {
+			c.now = end
+			c.mu.Unlock()
+
+			return
+		}
+
+		t := c.timers[0]
+		c.timers = c.timers[1:]
+		c.now = t.at
+		c.mu.Unlock()
+
+		// timer functions may create or stop timers, so they are called without lock.
+		t.f()
+	}
+}
+
+// Stop prevents timer from firing. It returns false if timer already fired or stopped.
+func (t *manualTimer) Stop() bool {
+	c := t.clock
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// TimeoutPenalty is what a player gets when shot clock runs out.
+type TimeoutPenalty int
+
+const (
+	// MissOnTimeout records turn as if no coin is pocketed.
+	MissOnTimeout TimeoutPenalty = iota
+	// FoulOnTimeout takes a point from player and counts turn as foul.
+	FoulOnTimeout
+)
+
+// TimeControl bounds thinking time of players. Zero value of a duration disables that clock.
+type TimeControl struct {
+	// ShotClock is time a player has for every turn.
+	ShotClock time.Duration
+	// ShotClockPenalty is applied and turn is passed when shot clock expires.
+	ShotClockPenalty TimeoutPenalty
+	// PlayerTime is chess-style time each player has for the whole game.
+	// A player who runs out of it forfeits the game.
+	PlayerTime time.Duration
+	// MatchTime is time for the whole game. When it runs out game is over.
+	MatchTime time.Duration
+	// Clock defaults to real time.
+	Clock Clock
+}
+
+func (tc TimeControl) isEnabled() bool {
+	return tc.ShotClock > 0 || tc.PlayerTime > 0 || tc.MatchTime > 0
+}
+
+// ClockState is remaining time of the clocks of current game.
+type ClockState struct {
+	Shot    time.Duration
+	Players map[string]time.Duration
+	Match   time.Duration
+	Paused  bool
+}
+
+// timeControl is clocks new games start with.
+var timeControl TimeControl
+
+// SetTimeControl sets clocks for the games started after it by NewBoard or NewGame.
+// Pass TimeControl{} to play without clocks.
+func SetTimeControl(tc TimeControl) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	timeControl = tc
+}
+
+// PauseClock stops all clocks of the game started by NewBoard until ResumeClock is called.
+func PauseClock() error {
+	return getDefaultGame().PauseClock()
+}
+
+// ResumeClock restarts clocks stopped by PauseClock.
+func ResumeClock() error {
+	return getDefaultGame().ResumeClock()
+}
+
+// GetClockState returns remaining time of the clocks of the game started by NewBoard.
+// false is returned if game is played without clocks.
+func GetClockState() (ClockState, bool) {
+	return getDefaultGame().ClockState()
+}
+
+// PauseClock stops all clocks of the game until ResumeClock is called.
+func (g *Game) PauseClock() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.clock == nil || g.clock.stopped {
+		return fmt.Errorf("no clock is running")
+	}
+
+	if g.clock.paused {
+		return fmt.Errorf("clock is already paused")
+	}
+
+	g.clock.charge()
+	g.clock.stopTimers()
+	g.clock.paused = true
+
+	return nil
+}
+
+// ResumeClock restarts clocks stopped by PauseClock.
+func (g *Game) ResumeClock() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.clock == nil || g.clock.stopped {
+		return fmt.Errorf("no clock is running")
+	}
+
+	if !g.clock.paused {
+		return fmt.Errorf("clock is not paused")
+	}
+
+	g.clock.paused = false
+	g.clock.schedule()
+
+	return nil
+}
+
+// ClockState returns remaining time of the clocks of the game.
+// false is returned if game is played without clocks.
+func (g *Game) ClockState() (ClockState, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	c := g.clock
+	if c == nil {
+		return ClockState{}, false
+	}
+
+	elapsed := time.Duration(0)
+
+	if !c.paused && !c.stopped {
+		elapsed = c.tc.Clock.Now().Sub(c.since)
+	}
+
+	state := ClockState{
+		Shot:    c.shot,
+		Players: make(map[string]time.Duration, len(c.players)),
+		Match:   c.match,
+		Paused:  c.paused,
+	}
+
+	for name, remaining := range c.players {
+		state.Players[name] = remaining
+	}
+
+	if c.tc.ShotClock > 0 {
+		state.Shot -= elapsed
+	}
+
+	if c.tc.PlayerTime > 0 {
+		state.Players[g.currentPlayer().PlayerName] -= elapsed
+	}
+
+	if c.tc.MatchTime > 0 {
+		state.Match -= elapsed
+	}
+
+	return state, true
+}
+
+// gameClock keeps remaining time of every clock. Remaining times are brought up to
+// date by charge, and timers for them are restarted by schedule.
+type gameClock struct {
+	g       *Game
+	tc      TimeControl
+	since   time.Time
+	shot    time.Duration
+	players map[string]time.Duration
+	match   time.Duration
+	timers  []Timer
+
+	// generation invalidates timers which fired while a turn was being played.
+	generation int
+	paused     bool
+	stopped    bool
+
+	// flagged is player who ran out of time and expired is set when match time runs out.
+	flagged *Player
+	expired bool
+}
+
+func newGameClock(g *Game, tc TimeControl) *gameClock {
+	c := &gameClock{
+		g:       g,
+		tc:      tc,
+		shot:    tc.ShotClock,
+		match:   tc.MatchTime,
+		players: make(map[string]time.Duration, len(g.players)),
+	}
+
+	for _, p := range g.players {
+		c.players[p.PlayerName] = tc.PlayerTime
+	}
+
+	c.schedule()
+
+	return c
+}
+
+// charge takes time elapsed since clock was last scheduled from running clocks.
+func (c *gameClock) charge() {
+	now := c.tc.Clock.Now()
+	elapsed := now.Sub(c.since)
+	c.since = now
+
+	if c.tc.ShotClock > 0 {
+		c.shot -= elapsed
+	}
+
+	if c.tc.PlayerTime > 0 {
+		c.players[c.g.currentPlayer().PlayerName] -= elapsed
+	}
+
+	if c.tc.MatchTime > 0 {
+		c.match -= elapsed
+	}
+}
+
+// isTimeUp returns true if a player or the match ran out of time.
+func (c *gameClock) isTimeUp() bool {
+	return c != nil && (c.flagged != nil || c.expired)
+}
+
+func (c *gameClock) schedule() {
+	c.since = c.tc.Clock.Now()
+	c.generation++
+
+	if c.tc.ShotClock > 0 {
+		c.startTimer(c.shot, c.shotExpired)
+	}
+
+	if c.tc.PlayerTime > 0 {
+		c.startTimer(c.players[c.g.currentPlayer().PlayerName], c.playerTimeExpired)
+	}
+
+	if c.tc.MatchTime > 0 {
+		c.startTimer(c.match, c.matchExpired)
+	}
+}
+
+func (c *gameClock) startTimer(d time.Duration, expire func()) {
+	generation := c.generation
+
+	c.timers = append(c.timers, c.tc.Clock.AfterFunc(d, func() {
+		c.g.mu.Lock()
+		defer c.g.mu.Unlock()
+
+		if generation != c.generation || c.paused || c.stopped {
+			return
+		}
+
+		c.charge()
+		c.stopTimers()
+		expire()
+	}))
+}
+
+func (c *gameClock) stopTimers() {
+	for _, t := range c.timers {
+		t.Stop()
+	}
+
+	c.timers = nil
+	c.generation++
+}
+
+// stop is called when game ends.
+func (c *gameClock) stop() {
+	c.stopTimers()
+	c.stopped = true
+}
+
+// turnPlayed is called after a valid turn, before turn is passed to next player.
+func (c *gameClock) turnPlayed() {
+	if c.paused {
+		return
+	}
+
+	c.charge()
+	c.stopTimers()
+}
+
+// turnStarted is called once turn is passed to next player.
+func (c *gameClock) turnStarted() {
+	c.shot = c.tc.ShotClock
+
+	if !c.paused {
+		c.schedule()
+	}
+}
+
+func (c *gameClock) shotExpired() {
+	c.g.saveTurn()
+
+	p := c.g.currentPlayer()
+	before := *p
+
+	c.g.log().WithField("player", p.PlayerName).Warnln("shot clock expired")
+
+	switch c.tc.ShotClockPenalty {
+	case FoulOnTimeout:
+		p.ShotClockFoul()
+	default:
+		p.NoPocket()
+	}
+
+	// no coin is pocketed in a turn the player ran out of time.
+	c.g.endTurn(p, before, []Input{{StrikeCode: 5}}, nil, true)
+	c.turnStarted()
+	c.g.checkEnd()
+}
+
+func (c *gameClock) playerTimeExpired() {
+	c.flagged = c.g.currentPlayer()
+	c.stop()
+
+	c.g.log().WithField("player", c.flagged.PlayerName).Warnln("player ran out of time")
+
+	c.g.checkEnd()
+}
+
+func (c *gameClock) matchExpired() {
+	c.expired = true
+	c.stop()
+
+	c.g.log().Warnln("match time is over")
+
+	c.g.checkEnd()
+}
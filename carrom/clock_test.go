@@ -0,0 +1,170 @@
+package carrom
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	l "github.com/sirupsen/logrus"
+)
+
+func startTimedGame(tc TimeControl) *ManualClock {
+	clock := NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	tc.Clock = clock
+
+	SetTimeControl(tc)
+	AddPlayersToGame([]string{"p1", "p2"})
+	NewBoard()
+
+	return clock
+}
+
+func TestShotClock(t *testing.T) {
+	defer SetTimeControl(TimeControl{})
+
+	testCases := []struct {
+		penalty        TimeoutPenalty
+		timeouts       int
+		expectedPoints int
+	}{
+		{MissOnTimeout, 4, 0},
+		{MissOnTimeout, 6, -1},
+		{FoulOnTimeout, 2, -1},
+		{FoulOnTimeout, 6, -4},
+	}
+
+	for _, tc := range testCases {
+		clock := startTimedGame(TimeControl{ShotClock: 10 * time.Second, ShotClockPenalty: tc.penalty})
+
+		for i := 0; i < tc.timeouts; i++ {
+			clock.Advance(10 * time.Second)
+		}
+
+		res, err := PlayTurn(Input{StrikeCode: 2})
+		if err != nil || res.PlayerName != "p1" || res.PointsDelta != 3 {
+			t.Errorf("PlayTurn() after %d timeouts= %+v, err: %v, want= player: p1, delta: 3", tc.timeouts, res, err)
+		}
+
+		state, _ := GetClockState()
+		if state.Shot != 10*time.Second {
+			t.Errorf("GetClockState()= shot: %v, want= shot: %v", state.Shot, 10*time.Second)
+		}
+
+		if p := defaultGame.players[1]; p.Points != tc.expectedPoints {
+			t.Errorf("%v x %d= p2 score: %d, want= score: %d", tc.penalty, tc.timeouts, p.Points, tc.expectedPoints)
+		}
+	}
+}
+
+func TestPlayerTime(t *testing.T) {
+	defer SetTimeControl(TimeControl{})
+
+	clock := startTimedGame(TimeControl{PlayerTime: time.Minute})
+
+	clock.Advance(50 * time.Second)
+
+	if _, err := PlayTurn(Input{StrikeCode: 5}); err != nil {
+		t.Fatalf("PlayTurn()= %v, want= nil", err)
+	}
+
+	clock.Advance(50 * time.Second)
+
+	state, _ := GetClockState()
+	if state.Players["p1"] != 10*time.Second || state.Players["p2"] != 10*time.Second {
+		t.Errorf("GetClockState()= %v, want= p1: 10s, p2: 10s", state.Players)
+	}
+
+	if IsGameOver() {
+		t.Fatalf("IsGameOver()= true, want= false")
+	}
+
+	clock.Advance(10 * time.Second)
+
+	if _, err := PlayTurn(Input{StrikeCode: 5}); err == nil {
+		t.Errorf("PlayTurn() after p2 ran out of time= nil, want= error")
+	}
+
+	if !IsGameOver() {
+		t.Errorf("IsGameOver()= false, want= true")
+	}
+}
+
+func TestPauseClock(t *testing.T) {
+	defer SetTimeControl(TimeControl{})
+
+	clock := startTimedGame(TimeControl{ShotClock: 10 * time.Second, MatchTime: time.Minute})
+
+	clock.Advance(5 * time.Second)
+
+	if err := PauseClock(); err != nil {
+		t.Fatalf("PauseClock()= %v, want= nil", err)
+	}
+
+	if err := PauseClock(); err == nil {
+		t.Errorf("PauseClock() on paused clock= nil, want= error")
+	}
+
+	clock.Advance(time.Hour)
+
+	if err := ResumeClock(); err != nil {
+		t.Fatalf("ResumeClock()= %v, want= nil", err)
+	}
+
+	state, _ := GetClockState()
+	if state.Shot != 5*time.Second || state.Match != 55*time.Second {
+		t.Errorf("GetClockState()= shot: %v, match: %v, want= shot: 5s, match: 55s", state.Shot, state.Match)
+	}
+
+	if res, _ := PlayTurn(Input{StrikeCode: 5}); res.PlayerName != "p1" {
+		t.Errorf("PlayTurn()= player: %s, want= player: p1", res.PlayerName)
+	}
+
+	clock.Advance(55 * time.Second)
+
+	if !IsGameOver() {
+		t.Errorf("IsGameOver() after match time= false, want= true")
+	}
+}
+
+func TestQuietClock(t *testing.T) {
+	var logged bytes.Buffer
+
+	out := l.StandardLogger().Out
+	l.SetOutput(&logged)
+
+	defer l.SetOutput(out)
+
+	for _, tc := range []TimeControl{
+		{ShotClock: 10 * time.Second, MatchTime: 25 * time.Second},
+		{ShotClock: 10 * time.Second, PlayerTime: 15 * time.Second},
+	} {
+		clock := NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+		tc.Clock = clock
+
+		g := NewGame("")
+		g.SetQuiet(true)
+		g.SetTimeControl(tc)
+
+		if err := g.AddPlayers([]string{"p1", "p2"}); err != nil {
+			t.Fatalf("AddPlayers()= %v, want= nil", err)
+		}
+
+		if _, err := g.Start(); err != nil {
+			t.Fatalf("Start()= %v, want= nil", err)
+		}
+
+		clock.Advance(10 * time.Second)
+		clock.Advance(10 * time.Second)
+		clock.Advance(5 * time.Second)
+
+		if !g.IsOver() || len(g.Turns()) != 2 {
+			t.Errorf("%+v: game over: %t after %d timeouts, want= over after 2", tc, g.IsOver(), len(g.Turns()))
+		}
+
+		g.Close()
+	}
+
+	if logged.Len() != 0 {
+		t.Errorf("clocks of quiet games logged %q, want= nothing", logged.String())
+	}
+}
@@ -22,29 +22,53 @@ var (
 	white = "white"
 )
 
-// CoinsOnBoard gives coins and it's current count.
+// CoinsOnBoard gives coins and it's current count of the board started by NewBoard.
+// Reading it while turns are played races with them, use Snapshot instead.
 var CoinsOnBoard *Coins
 
-func removeCoin(coinColor string, removalCount int) {
+// coins returns coins of the board player is playing on.
+func (p *Player) coins() *Coins {
+	if p.board != nil {
+		return p.board
+	}
+
+	return CoinsOnBoard
+}
+
+// remove takes up to removalCount coins of the colour and returns count of coins taken.
+func (c *Coins) remove(coinColor string, removalCount int) int {
+	count := c.count(coinColor)
+	if count == nil {
+		l.Errorln("invalid color: ", coinColor)
+
+		return 0
+	}
+
+	if coinColor == red || *count < removalCount {
+		removalCount = *count
+	}
+
+	*count -= removalCount
+
+	return removalCount
+}
+
+// add puts count coins of the colour.
+func (c *Coins) add(coinColor string, count int) {
+	if n := c.count(coinColor); n != nil {
+		*n += count
+	}
+}
+
+func (c *Coins) count(coinColor string) *int {
 	switch coinColor {
 	case black:
-		if CoinsOnBoard.Black > removalCount {
-			CoinsOnBoard.Black -= removalCount
-
-			return
-		}
-		CoinsOnBoard.Black = 0
+		return &c.Black
 	case white:
-		if CoinsOnBoard.White > removalCount {
-			CoinsOnBoard.White -= removalCount
-
-			return
-		}
-		CoinsOnBoard.White = 0
+		return &c.White
 	case red:
-		CoinsOnBoard.Red = 0
-
-	default:
-		l.Errorln("invalid color: ", coinColor)
+		return &c.Red
 	}
+
+	return nil
 }
@@ -0,0 +1,176 @@
+package carrom
+
+import (
+	"fmt"
+
+	l "github.com/sirupsen/logrus"
+)
+
+// DrawProcedure decides a game which would end in draw.
+type DrawProcedure int
+
+const (
+	// AllowDraws lets games end in draw.
+	AllowDraws DrawProcedure = iota
+	// SuddenDeathReRack puts all coins back on board and plays rounds of one turn for every
+	// player until a round ends with a leader who is not tied.
+	SuddenDeathReRack
+	// Shootout puts all coins back on board and plays a fixed number of rounds, after which
+	// the leader wins.
+	Shootout
+	// DecideByTieBreakers wins the game for the leader, separating players tied on points
+	// by the tie breakers of the draw break.
+	DecideByTieBreakers
+)
+
+// DrawBreak decides games which would end in draw, for the games which need a winner
+// such as knockout tournaments. Games which can't be decided by the procedure, eg. because
+// time is up, are decided by TieBreakers. A game is drawn only if players are still tied.
+type DrawBreak struct {
+	Procedure DrawProcedure
+	// Rounds is number of rounds of a shootout and most rounds of sudden death. 0 is one round.
+	Rounds      int
+	TieBreakers []TieBreaker
+}
+
+func (d DrawBreak) validate() error {
+	if d.Procedure < AllowDraws || d.Procedure > DecideByTieBreakers || d.Rounds < 0 {
+		return fmt.Errorf("invalid draw break %+v", d)
+	}
+
+	return Ranking{TieBreakers: d.TieBreakers}.validate()
+}
+
+func (d DrawBreak) copy() DrawBreak {
+	d.TieBreakers = append([]TieBreaker(nil), d.TieBreakers...)
+
+	return d
+}
+
+func (d DrawBreak) rounds() int {
+	if d.Rounds == 0 {
+		return 1
+	}
+
+	return d.Rounds
+}
+
+func (d DrawBreak) reason() string {
+	if d.Procedure == Shootout {
+		return ReasonShootout
+	}
+
+	return ReasonSuddenDeath
+}
+
+// drawBreak is the draw break new games start with.
+var drawBreak DrawBreak
+
+// SetDrawBreak sets draw break of the games created after it by NewBoard or NewGame.
+// Pass DrawBreak{} to allow draws.
+func SetDrawBreak(d DrawBreak) error {
+	if err := d.validate(); err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	drawBreak = d.copy()
+
+	return nil
+}
+
+// SetDrawBreak sets draw break of a game being set up.
+func (g *Game) SetDrawBreak(d DrawBreak) error {
+	if err := d.validate(); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("set draw break", Setup); err != nil {
+		return err
+	}
+
+	g.drawBreak = d.copy()
+
+	return nil
+}
+
+// breakDraw is called for a game which would end in draw for reason. The game is either
+// decided, or the board is re-racked and ended is false.
+func (g *Game) breakDraw(reason string) (winner *Player, why string, ended bool) {
+	d := g.drawBreak
+
+	if g.deciding || g.clock.isTimeUp() || d.Procedure == DecideByTieBreakers {
+		if winner := g.tieBreakWinner(); winner != nil {
+			return winner, ReasonTieBreak, true
+		}
+
+		return nil, reason, true
+	}
+
+	g.coins.Red, g.coins.White, g.coins.Black = 1, 9, 9
+
+	for _, p := range g.players {
+		p.Pocketed = Coins{}
+	}
+	g.deciding = true
+	g.decidingFrom = g.turnCount
+
+	g.log().WithFields(l.Fields{"reason": reason, "decidedBy": d.reason()}).Warnln("draw is not allowed, board is re-racked")
+
+	return nil, "", false
+}
+
+// decide returns the result of sudden death or shootout played on a re-racked board.
+// winner is nil if game is to be decided by breakDraw.
+func (g *Game) decide() (winner *Player, reason string, ended bool) {
+	d := g.drawBreak
+	played, round := g.turnCount-g.decidingFrom, len(g.ranked(nil))
+
+	if g.isBoardEmpty() {
+		return nil, d.reason(), true
+	}
+
+	if played == 0 || played%round != 0 {
+		return nil, "", false
+	}
+
+	if leader := g.leader(); leader != nil && d.Procedure == SuddenDeathReRack {
+		return leader, d.reason(), true
+	}
+
+	if played/round < d.rounds() {
+		return nil, "", false
+	}
+
+	return g.leader(), d.reason(), true
+}
+
+// tieBreakWinner returns the player placed first by points and the tie breakers of the
+// draw break, or nil if he is tied with the player after him.
+func (g *Game) tieBreakWinner() *Player {
+	r := Ranking{TieBreakers: g.drawBreak.TieBreakers}
+	players := g.ranked(nil)
+
+	for i := 1; i < len(players); i++ {
+		if r.compare(players[i], players[0]) < 0 {
+			players[0], players[i] = players[i], players[0]
+		}
+	}
+
+	if len(players) == 1 {
+		return players[0]
+	}
+
+	for _, p := range players[1:] {
+		if r.compare(players[0], p) >= 0 {
+			return nil
+		}
+	}
+
+	return players[0]
+}
@@ -0,0 +1,111 @@
+package carrom_test
+
+import (
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestDrawBreak(t *testing.T) {
+	miss := carrom.Input{StrikeCode: 5}
+	red := []carrom.TieBreaker{carrom.MoreRedPocketed}
+
+	testCases := []struct {
+		drawBreak      carrom.DrawBreak
+		inputs         []carrom.Input
+		expectedKind   carrom.OutcomeKind
+		expectedWinner string
+		expectedReason string
+	}{
+		{carrom.DrawBreak{}, nil, carrom.OutcomeDrawn, "", carrom.ReasonBoardExhausted},
+		{carrom.DrawBreak{Procedure: carrom.DecideByTieBreakers}, nil, carrom.OutcomeDrawn, "", carrom.ReasonBoardExhausted},
+		{carrom.DrawBreak{Procedure: carrom.DecideByTieBreakers, TieBreakers: red}, nil, carrom.OutcomeWon, "p1", carrom.ReasonTieBreak},
+		{carrom.DrawBreak{Procedure: carrom.SuddenDeathReRack}, []carrom.Input{miss, {0, b(1)}}, carrom.OutcomeWon, "p2", carrom.ReasonSuddenDeath},
+		{carrom.DrawBreak{Procedure: carrom.SuddenDeathReRack}, []carrom.Input{miss, miss}, carrom.OutcomeDrawn, "", carrom.ReasonSuddenDeath},
+		{carrom.DrawBreak{Procedure: carrom.SuddenDeathReRack, Rounds: 2}, []carrom.Input{miss, miss, {0, b(1)}, miss}, carrom.OutcomeWon, "p1", carrom.ReasonSuddenDeath},
+		{carrom.DrawBreak{Procedure: carrom.Shootout, Rounds: 2}, []carrom.Input{{0, b(1)}, miss, miss, miss}, carrom.OutcomeWon, "p1", carrom.ReasonShootout},
+		{carrom.DrawBreak{Procedure: carrom.Shootout, Rounds: 2}, []carrom.Input{{0, b(1)}, miss, miss, {0, b(1)}}, carrom.OutcomeDrawn, "", carrom.ReasonShootout},
+		{carrom.DrawBreak{Procedure: carrom.Shootout, Rounds: 2, TieBreakers: red}, []carrom.Input{{0, b(1)}, miss, miss, {0, b(1)}}, carrom.OutcomeWon, "p1", carrom.ReasonTieBreak},
+	}
+
+	for _, tc := range testCases {
+		g := carrom.NewGame("")
+		if err := g.SetDrawBreak(tc.drawBreak); err != nil {
+			t.Fatalf("SetDrawBreak()= %v, want= nil", err)
+		}
+
+		if err := g.AddPlayers([]string{"p1", "p2"}); err != nil {
+			t.Fatalf("AddPlayers()= %v, want= nil", err)
+		}
+
+		if _, err := g.Start(); err != nil {
+			t.Fatalf("Start()= %v, want= nil", err)
+		}
+
+		for _, c := range append(append([]carrom.Input(nil), drawnBoard...), tc.inputs...) {
+			if _, err := g.PlayTurn(c); err != nil {
+				t.Fatalf("PlayTurn(%v) with %+v= %v, want= nil", c, tc.drawBreak, err)
+			}
+		}
+
+		o := g.Outcome()
+		if o.Kind != tc.expectedKind || o.Winner != tc.expectedWinner || o.Reason != tc.expectedReason {
+			t.Errorf("Outcome() with %+v= %v, %q, %q, want= %v, %q, %q",
+				tc.drawBreak, o.Kind, o.Winner, o.Reason, tc.expectedKind, tc.expectedWinner, tc.expectedReason)
+		}
+
+		g.Close()
+	}
+}
+
+func TestDrawBreakReRackUndo(t *testing.T) {
+	g := carrom.NewGame("")
+	_ = g.SetDrawBreak(carrom.DrawBreak{Procedure: carrom.SuddenDeathReRack})
+	_ = g.AddPlayers([]string{"p1", "p2"})
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	playTurnsOn(t, g, drawnBoard...)
+
+	if c := g.Coins(); g.IsOver() || c != (carrom.Coins{Red: 1, White: 9, Black: 9}) {
+		t.Fatalf("Coins() after draw= %+v, over: %t, want= re-racked board", c, g.IsOver())
+	}
+
+	if p1 := g.Players()[0]; p1.Pocketed != (carrom.Coins{}) {
+		t.Errorf("Pocketed after re-rack= %+v, want= none", p1.Pocketed)
+	}
+
+	if _, ok := g.Position(); ok {
+		t.Errorf("Position() of re-racked board= true, want= false")
+	}
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo()= %v, want= nil", err)
+	}
+
+	if c := g.Coins(); c != (carrom.Coins{White: 9}) {
+		t.Errorf("Coins() after Undo()= %+v, want= 9 white", c)
+	}
+
+	if p1 := g.Players()[0]; p1.Pocketed != (carrom.Coins{Red: 1, Black: 9}) {
+		t.Errorf("Pocketed after Undo()= %+v, want= red and 9 black", p1.Pocketed)
+	}
+
+	if _, ok := g.Position(); !ok {
+		t.Errorf("Position() after Undo()= false, want= true")
+	}
+}
+
+func playTurnsOn(t *testing.T, g *carrom.Game, inputs ...carrom.Input) {
+	t.Helper()
+
+	for _, c := range inputs {
+		if _, err := g.PlayTurn(c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package carrom
+
+import (
+	"fmt"
+
+	l "github.com/sirupsen/logrus"
+)
+
+// Elimination knocks players out of a game. Knocked out players play no more turns and are
+// placed below the players still in the game, the last one knocked out first.
+// A game in elimination mode is not won by a lead. It ends once one player remains, who wins it,
+// or once the board is exhausted, when the leader of the remaining players wins unless he is tied.
+type Elimination struct {
+	// Enabled turns elimination mode on.
+	Enabled bool
+	// MinPoints knocks out a player whose points drop below it.
+	MinPoints int
+	// MaxFouls knocks out a player who fouls more times in the game. 0 is no limit.
+	MaxFouls int
+}
+
+func (e Elimination) validate() error {
+	if e.MaxFouls < 0 {
+		return fmt.Errorf("invalid elimination %+v", e)
+	}
+
+	return nil
+}
+
+// elimination is the elimination new games start with.
+var elimination Elimination
+
+// SetElimination sets elimination mode of the games created after it by NewBoard or NewGame.
+// Pass Elimination{} to play without elimination.
+func SetElimination(e Elimination) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	elimination = e
+
+	return nil
+}
+
+// SetElimination sets elimination mode of a game being set up.
+func (g *Game) SetElimination(e Elimination) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("set elimination", Setup); err != nil {
+		return err
+	}
+
+	g.elimination = e
+
+	return nil
+}
+
+// eliminate knocks p out of game if elimination mode is on and p dropped below the
+// thresholds. The last player in game is never knocked out.
+func (g *Game) eliminate(p *Player) bool {
+	e := g.elimination
+
+	if !e.Enabled || p.eliminated || len(g.ranked(nil)) == 1 {
+		return false
+	}
+
+	if p.Points >= e.MinPoints && (e.MaxFouls == 0 || p.totalFouls() <= e.MaxFouls) {
+		return false
+	}
+
+	p.eliminated = true
+	g.eliminated = append(g.eliminated, p)
+
+	g.log().WithFields(l.Fields{"player": p.PlayerName, "points": p.Points, "fouls": p.totalFouls()}).Warnln("player eliminated")
+
+	return true
+}
+
+// lastStanding returns winner of a game in elimination mode.
+func (g *Game) lastStanding() *Player {
+	players := g.ranked(nil)
+
+	if len(players) == 1 {
+		return players[0]
+	}
+
+	if g.isBoardEmpty() && g.ranking.compare(players[0], players[1]) < 0 {
+		return players[0]
+	}
+
+	return nil
+}
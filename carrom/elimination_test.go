@@ -0,0 +1,114 @@
+package carrom_test
+
+import (
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestElimination(t *testing.T) {
+	testCases := []struct {
+		elimination    carrom.Elimination
+		inputs         []carrom.Input
+		expectedTurns  []string
+		expectedKind   carrom.OutcomeKind
+		expectedReason string
+		expectedOrder  []string
+	}{
+		{
+			carrom.Elimination{Enabled: true, MinPoints: -1},
+			[]carrom.Input{{StrikeCode: 3}, {StrikeCode: 5}, {StrikeCode: 5}, {StrikeCode: 3}, {StrikeCode: 5}, {4, b(1)}},
+			[]string{"p1", "p2", "p3", "p1", "p2", "p3"},
+			carrom.OutcomeWon, carrom.ReasonLastStanding, []string{"p2", "p3", "p1"},
+		},
+		{
+			carrom.Elimination{Enabled: true, MinPoints: -10, MaxFouls: 1},
+			[]carrom.Input{{StrikeCode: 3}, {StrikeCode: 3}, {StrikeCode: 5}, {StrikeCode: 3}, {StrikeCode: 5}, {StrikeCode: 2}},
+			[]string{"p1", "p2", "p3", "p1", "p2", "p3"},
+			carrom.OutcomeOngoing, "", []string{"p3", "p2", "p1"},
+		},
+		{
+			carrom.Elimination{Enabled: true, MinPoints: -10},
+			[]carrom.Input{
+				{1, carrom.CoinsPocketedCount{Black: 9, IsRedPocketed: true}}, {StrikeCode: 3},
+				{1, carrom.CoinsPocketedCount{White: 8}}, {0, carrom.CoinsPocketedCount{White: 1}},
+			},
+			[]string{"p1", "p2", "p3", "p1"},
+			carrom.OutcomeWon, carrom.ReasonBoardExhausted, []string{"p1", "p3", "p2"},
+		},
+		{
+			carrom.Elimination{},
+			[]carrom.Input{
+				{1, carrom.CoinsPocketedCount{Black: 9, IsRedPocketed: true}}, {StrikeCode: 3},
+				{1, carrom.CoinsPocketedCount{White: 8}}, {0, carrom.CoinsPocketedCount{White: 1}},
+			},
+			[]string{"p1", "p2", "p3", "p1"},
+			carrom.OutcomeDrawn, carrom.ReasonBoardExhausted, []string{"p1", "p3", "p2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		g := carrom.NewGame("")
+		if err := g.SetElimination(tc.elimination); err != nil {
+			t.Fatalf("SetElimination()= %v, want= nil", err)
+		}
+
+		if err := g.AddPlayers([]string{"p1", "p2", "p3"}); err != nil {
+			t.Fatalf("AddPlayers()= %v, want= nil", err)
+		}
+
+		if _, err := g.Start(); err != nil {
+			t.Fatalf("Start()= %v, want= nil", err)
+		}
+
+		for i, c := range tc.inputs {
+			res, err := g.PlayTurn(c)
+			if err != nil || res.PlayerName != tc.expectedTurns[i] {
+				t.Fatalf("PlayTurn(%v)= %+v, err: %v, want= turn of %s", c, res, err, tc.expectedTurns[i])
+			}
+		}
+
+		o := g.Outcome()
+		if o.Kind != tc.expectedKind || o.Reason != tc.expectedReason {
+			t.Errorf("Outcome() with %+v= %v, %q, want= %v, %q", tc.elimination, o.Kind, o.Reason, tc.expectedKind, tc.expectedReason)
+		}
+
+		for i, s := range o.Standings {
+			if s.PlayerName != tc.expectedOrder[i] || s.Place != i+1 {
+				t.Errorf("Standings[%d] with %+v= %s at %d, want= %s at %d", i, tc.elimination, s.PlayerName, s.Place, tc.expectedOrder[i], i+1)
+			}
+		}
+
+		g.Close()
+	}
+}
+
+func TestEliminationUndo(t *testing.T) {
+	g := carrom.NewGame("")
+	_ = g.SetElimination(carrom.Elimination{Enabled: true, MinPoints: 0})
+	_ = g.AddPlayers([]string{"p1", "p2", "p3"})
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	if res, _ := g.PlayTurn(carrom.Input{StrikeCode: 3}); !res.Eliminated {
+		t.Fatalf("PlayTurn()= %+v, want= p1 eliminated", res)
+	}
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo()= %v, want= nil", err)
+	}
+
+	if res, _ := g.PlayTurn(carrom.Input{StrikeCode: 5}); res.PlayerName != "p1" || res.Eliminated {
+		t.Errorf("PlayTurn() after Undo()= %+v, want= turn of p1", res)
+	}
+
+	for _, s := range g.Outcome().Standings {
+		if s.Eliminated {
+			t.Errorf("Standings after Undo()= %+v eliminated, want= nobody eliminated", s)
+		}
+	}
+}
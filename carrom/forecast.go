@@ -0,0 +1,381 @@
+package carrom
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// ShotModel is how likely turns of a player are to be each of the strikes, given as relative
+// weights of the strike codes 0 to 5, see StrikeCodeInput.
+type ShotModel [6]float64
+
+// Validate returns an error for models with negative weights or no strike possible.
+func (m ShotModel) Validate() error {
+	total := 0.0
+
+	for _, w := range m {
+		if w < 0 {
+			return fmt.Errorf("invalid shot model %v", m)
+		}
+
+		total += w
+	}
+
+	if total == 0 {
+		return fmt.Errorf("invalid shot model %v: no strike is possible", m)
+	}
+
+	return nil
+}
+
+// averageShotModel is the model of players without one. It misses more than half of the turns.
+var averageShotModel = ShotModel{30, 5, 4, 5, 2, 54}
+
+// priorTurns is how many turns of a player the average model weighs as in ShotModelFromTurns.
+const priorTurns = 20
+
+// ShotModelFromTurns returns model of the player from his turns, eg. turns of his past games
+// read from event logs. The average model weighs as much as 20 of his turns, so the model of
+// a player with few turns is close to average. Fouls declared by the umpire are not counted.
+func ShotModelFromTurns(playerName string, turns []TurnResult) ShotModel {
+	var m ShotModel
+
+	total := 0.0
+	for _, w := range averageShotModel {
+		total += w
+	}
+
+	for code, w := range averageShotModel {
+		m[code] = w / total * priorTurns
+	}
+
+	for _, turn := range turns {
+		if turn.PlayerName != playerName || turn.Foul != 0 {
+			continue
+		}
+
+		strikes := turn.Strikes
+		if len(strikes) == 0 {
+			strikes = []Input{turn.Input}
+		}
+
+		for _, c := range strikes {
+			if c.StrikeCode >= 0 && c.StrikeCode < len(m) {
+				m[c.StrikeCode]++
+			}
+		}
+	}
+
+	return m
+}
+
+// Sample returns input of a turn drawn from the model, which is valid on a board with coins.
+// Strikes which aren't possible on the board, eg. red strike once red is pocketed, are missed.
+func (m ShotModel) Sample(r *rand.Rand, coins Coins) Input {
+	total := 0.0
+	for _, w := range m {
+		total += w
+	}
+
+	code, pick := 0, r.Float64()*total
+	for code < len(m)-1 && pick >= m[code] {
+		pick -= m[code]
+		code++
+	}
+
+	coin := func() CoinsPocketedCount {
+		if r.Intn(coins.Black+coins.White) < coins.Black {
+			coins.Black--
+
+			return CoinsPocketedCount{Black: 1}
+		}
+
+		coins.White--
+
+		return CoinsPocketedCount{White: 1}
+	}
+
+	miss := Input{StrikeCode: 5}
+
+	switch {
+	case code == 0 || code == 1 || code == 4:
+		if coins.Black+coins.White == 0 {
+			return miss
+		}
+
+		c := Input{StrikeCode: code, CoinsPocketedCount: coin()}
+
+		if code == 1 {
+			if coins.Black+coins.White == 0 {
+				return Input{StrikeCode: 0, CoinsPocketedCount: c.CoinsPocketedCount}
+			}
+
+			second := coin()
+			c.Black += second.Black
+			c.White += second.White
+		}
+
+		return c
+	case code == 2 && coins.Red == 0:
+		return miss
+	}
+
+	return Input{StrikeCode: code}
+}
+
+// Forecast estimates chances of players to win from the current position of a game by playing
+// it out many times, every player playing turns drawn from his shot model. Games played out
+// follow the rules of the game, such as its turn cap and draw break.
+type Forecast struct {
+	// Rollouts is count of games played out. 0 turns forecast off.
+	Rollouts int
+	// Models are shot models by player name. Players without one play average turns.
+	Models map[string]ShotModel
+	// Seed seeds the turns drawn, so the same forecast of a position gives the same chances.
+	Seed int64
+}
+
+func (f Forecast) validate() error {
+	if f.Rollouts < 0 {
+		return fmt.Errorf("invalid forecast of %d rollouts", f.Rollouts)
+	}
+
+	for name, m := range f.Models {
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("player %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (f Forecast) copy() Forecast {
+	models := make(map[string]ShotModel, len(f.Models))
+	for name, m := range f.Models {
+		models[name] = m
+	}
+
+	f.Models = models
+
+	return f
+}
+
+func (f Forecast) model(playerName string) ShotModel {
+	if m, ok := f.Models[playerName]; ok {
+		return m
+	}
+
+	return averageShotModel
+}
+
+// Chances are chances of a player to win, draw and lose a game, adding up to 1.
+type Chances struct {
+	Win  float64
+	Draw float64
+	Loss float64
+}
+
+// maxRolloutTurns ends games played out by a forecast which go on without being decided.
+// They are counted as draws.
+const maxRolloutTurns = 1000
+
+// forecast is the forecast new games start with.
+var forecast Forecast
+
+// SetForecast sets forecast shown in snapshots and score boards of the games created after it
+// by NewBoard or NewGame. Pass Forecast{} to show none.
+func SetForecast(f Forecast) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	forecast = f.copy()
+
+	return nil
+}
+
+// SetForecast sets forecast shown in snapshots and score board of the game.
+func (g *Game) SetForecast(f Forecast) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.forecast = f.copy()
+	g.newPosition()
+
+	return nil
+}
+
+// WinChances returns chances of players of the game by their names, estimated by f.
+// Chances of a game which is over are its outcome, and games abandoned are lost by all.
+func (g *Game) WinChances(f Forecast) (map[string]Chances, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	if f.Rollouts == 0 {
+		return nil, fmt.Errorf("forecast of no rollouts")
+	}
+
+	g.mu.Lock()
+
+	if err := g.allow("forecast", InProgress, Finished, Drawn, Abandoned); err != nil {
+		g.mu.Unlock()
+
+		return nil, err
+	}
+
+	// chances of games over need no rollouts, and games abandoned in setup have no board to copy.
+	if g.state.IsOver() {
+		defer g.mu.Unlock()
+
+		return g.winChances(f)
+	}
+
+	// rollouts are played on a copy, so turns are not held up by them.
+	sim := g.clone("", false)
+	g.mu.Unlock()
+
+	return sim.winChances(f)
+}
+
+// WinChances returns chances of players of the game started by NewBoard.
+func WinChances(f Forecast) (map[string]Chances, error) {
+	return getDefaultGame().WinChances(f)
+}
+
+// winChances must be called holding lock of the game, or on a copy of it no one else uses.
+func (g *Game) winChances(f Forecast) (map[string]Chances, error) {
+	chances := make(map[string]Chances, len(g.players))
+
+	if g.state.IsOver() {
+		for _, p := range g.players {
+			switch {
+			case g.state == Drawn:
+				chances[p.PlayerName] = Chances{Draw: 1}
+			case g.outcome.Winner == p.PlayerName:
+				chances[p.PlayerName] = Chances{Win: 1}
+			default:
+				chances[p.PlayerName] = Chances{Loss: 1}
+			}
+		}
+
+		return chances, nil
+	}
+
+	wins := make(map[string]int, len(g.players))
+	draws := 0
+	r := rand.New(rand.NewSource(f.Seed))
+
+	for i := 0; i < f.Rollouts; i++ {
+		winner, err := g.rollout(r, f)
+		if err != nil {
+			return nil, err
+		}
+
+		if winner == "" {
+			draws++
+		} else {
+			wins[winner]++
+		}
+	}
+
+	rollouts := float64(f.Rollouts)
+
+	for _, p := range g.players {
+		won := wins[p.PlayerName]
+		chances[p.PlayerName] = Chances{
+			Win:  float64(won) / rollouts,
+			Draw: float64(draws) / rollouts,
+			Loss: float64(f.Rollouts-won-draws) / rollouts,
+		}
+	}
+
+	return chances, nil
+}
+
+// rollout plays out the game once on a copy of it and returns its winner, empty if it is drawn.
+func (g *Game) rollout(r *rand.Rand, f Forecast) (string, error) {
+	sim := g.clone("", false)
+	sim.quiet = true
+
+	for turns := 0; !sim.state.IsOver(); turns++ {
+		if turns == maxRolloutTurns {
+			return "", nil
+		}
+
+		c := f.model(sim.currentPlayer().PlayerName).Sample(r, *sim.coins)
+
+		if _, err := sim.play([]Input{c}, nil); err != nil {
+			return "", fmt.Errorf("forecast: %w", err)
+		}
+	}
+
+	return sim.outcome.Winner, nil
+}
+
+// newPosition drops chances estimated for the position of the game, which has changed.
+// It must be called holding lock of the game.
+func (g *Game) newPosition() {
+	g.chances = nil
+	g.position++
+}
+
+// chancesJob estimates chances of a position of a game without holding its lock, so turns
+// and other queries are not held up by its rollouts.
+type chancesJob struct {
+	game     *Game
+	position int
+	forecast Forecast
+	// sim is a copy of the game at the position, nil if chances are known.
+	sim     *Game
+	chances map[string]Chances
+}
+
+// chancesJob returns job estimating chances of the game by its forecast. It must be called
+// holding lock of the game, and the job run after releasing it.
+func (g *Game) chancesJob() chancesJob {
+	j := chancesJob{game: g, position: g.position, forecast: g.forecast}
+
+	if g.forecast.Rollouts == 0 || g.state == Setup || g.state == Abandoned {
+		return j
+	}
+
+	j.chances = g.chances
+	if j.chances == nil {
+		j.sim = g.clone("", false)
+	}
+
+	return j
+}
+
+// run returns chances of the position, nil if the game has no forecast. Chances estimated are
+// kept by the game until its position changes.
+func (j chancesJob) run() map[string]Chances {
+	if j.sim == nil {
+		return j.chances
+	}
+
+	chances, err := j.sim.winChances(j.forecast)
+
+	j.game.mu.Lock()
+	defer j.game.mu.Unlock()
+
+	if err != nil {
+		j.game.log().WithError(err).Errorln("failed to forecast")
+
+		return nil
+	}
+
+	if j.game.position == j.position {
+		j.game.chances = chances
+	}
+
+	return chances
+}
@@ -0,0 +1,175 @@
+package carrom_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestWinChances(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	playTurnsOn(t, g, carrom.Input{StrikeCode: 2})
+
+	f := carrom.Forecast{Rollouts: 500, Seed: 1}
+
+	chances, err := g.WinChances(f)
+	if err != nil {
+		t.Fatalf("WinChances()= %v, want= nil", err)
+	}
+
+	for name, c := range chances {
+		if math.Abs(c.Win+c.Draw+c.Loss-1) > 1e-9 {
+			t.Errorf("WinChances()[%s]= %+v, want= adding up to 1", name, c)
+		}
+	}
+
+	if chances["p1"].Win <= chances["p2"].Win {
+		t.Errorf("WinChances()= %+v, want= p1 leading by 3 more likely to win", chances)
+	}
+
+	if again, _ := g.WinChances(f); again["p1"] != chances["p1"] {
+		t.Errorf("WinChances() again= %+v, want= %+v with the same seed", again, chances)
+	}
+
+	// p2 pocketing a coin every turn wins though he is behind.
+	f.Models = map[string]carrom.ShotModel{"p2": {1, 0, 0, 0, 0, 0}}
+
+	if chances, _ := g.WinChances(f); chances["p2"].Win <= chances["p1"].Win {
+		t.Errorf("WinChances() with sharp p2= %+v, want= p2 likely to win", chances)
+	}
+}
+
+func TestWinChancesOver(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	playTurnsOn(t, g, breakerWins...)
+
+	chances, err := g.WinChances(carrom.Forecast{Rollouts: 10})
+	if err != nil || chances["p1"] != (carrom.Chances{Win: 1}) || chances["p2"] != (carrom.Chances{Loss: 1}) {
+		t.Errorf("WinChances() of finished game= %+v, err: %v, want= p1 won", chances, err)
+	}
+}
+
+func TestWinChancesInvalid(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	testCases := []carrom.Forecast{
+		{},
+		{Rollouts: -1},
+		{Rollouts: 10, Models: map[string]carrom.ShotModel{"p1": {}}},
+		{Rollouts: 10, Models: map[string]carrom.ShotModel{"p1": {1, -1}}},
+	}
+
+	for _, f := range testCases {
+		if _, err := g.WinChances(f); err == nil {
+			t.Errorf("WinChances(%+v)= nil, want= error", f)
+		}
+	}
+
+	if _, err := carrom.NewGame("").WinChances(carrom.Forecast{Rollouts: 10}); err == nil {
+		t.Errorf("WinChances() of game in setup= nil, want= error")
+	}
+}
+
+func TestWinChancesAbandonedInSetup(t *testing.T) {
+	g := carrom.NewGame("")
+	if err := g.AddPlayers([]string{"p1", "p2"}); err != nil {
+		t.Fatalf("AddPlayers()= %v, want= nil", err)
+	}
+
+	if err := g.Abandon(); err != nil {
+		t.Fatalf("Abandon()= %v, want= nil", err)
+	}
+
+	chances, err := g.WinChances(carrom.Forecast{Rollouts: 10})
+	if err != nil || chances["p1"] != (carrom.Chances{Loss: 1}) || chances["p2"] != (carrom.Chances{Loss: 1}) {
+		t.Errorf("WinChances() of game abandoned in setup= %v, err: %v, want= lost by all", chances, err)
+	}
+}
+
+func TestForecastInSnapshot(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	if s := g.Snapshot(); s.Players[0].Chances != nil {
+		t.Errorf("Snapshot() without forecast= %+v, want= no chances", s.Players[0])
+	}
+
+	if err := g.SetForecast(carrom.Forecast{Rollouts: 200}); err != nil {
+		t.Fatalf("SetForecast()= %v, want= nil", err)
+	}
+
+	before := *g.Snapshot().Players[0].Chances
+
+	playTurnsOn(t, g, carrom.Input{StrikeCode: 2})
+
+	after := g.Snapshot().Players[0].Chances
+	if after == nil || after.Win <= before.Win {
+		t.Errorf("Snapshot().Chances after red strike= %+v, want= more than %+v", after, before)
+	}
+
+	if board := g.ScoreBoard(); !strings.Contains(board, "Win %") || !strings.Contains(board, "p1") {
+		t.Errorf("ScoreBoard()= %s, want= chances of players", board)
+	}
+}
+
+func TestShotModelFromTurns(t *testing.T) {
+	turns := []carrom.TurnResult{
+		{PlayerName: "p1", Input: carrom.Input{StrikeCode: 0}},
+		{PlayerName: "p1", Input: carrom.Input{StrikeCode: 0}},
+		{PlayerName: "p1", Input: carrom.Input{StrikeCode: 3}, Strikes: []carrom.Input{{StrikeCode: 3}, {StrikeCode: 2}}},
+		{PlayerName: "p1", Foul: carrom.FoulTouchingCoin},
+		{PlayerName: "p2", Input: carrom.Input{StrikeCode: 5}},
+	}
+
+	average := carrom.ShotModelFromTurns("p3", nil)
+	m := carrom.ShotModelFromTurns("p1", turns)
+
+	for code, expected := range []float64{2, 0, 1, 1, 0, 0} {
+		if delta := m[code] - average[code]; math.Abs(delta-expected) > 1e-9 {
+			t.Errorf("ShotModelFromTurns()[%d]= %g over average, want= %g", code, delta, expected)
+		}
+	}
+}
+
+func TestSnapshotDoesNotHoldUpTurns(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	if err := g.SetForecast(carrom.Forecast{Rollouts: 50000, Seed: 1}); err != nil {
+		t.Fatalf("SetForecast()= %v, want= nil", err)
+	}
+
+	snapshots := make(chan carrom.GameSnapshot)
+	go func() { snapshots <- g.Snapshot() }()
+
+	// let the snapshot start its rollouts.
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := g.PlayTurn(carrom.Input{StrikeCode: 2}); err != nil {
+		t.Fatalf("PlayTurn()= %v, want= nil", err)
+	}
+
+	select {
+	case <-snapshots:
+		t.Errorf("Snapshot() was over before the turn played while it ran, want= turn not held up")
+	default:
+	}
+
+	s := <-snapshots
+	if s.Players[0].Chances == nil {
+		t.Fatalf("Snapshot()= %+v, want= chances", s)
+	}
+
+	// chances are of the position taken, before p1 pocketed the red.
+	if s.Turn != 0 || s.Players[0].Points != 0 {
+		t.Errorf("Snapshot()= turn %d with p1 on %d points, want= turn 0", s.Turn, s.Players[0].Points)
+	}
+}
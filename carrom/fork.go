@@ -0,0 +1,110 @@
+package carrom
+
+import "fmt"
+
+// Fork returns an independent copy of the game as it was after the first turns of Turns,
+// with id as its ID, eg. to play "what if" turns and compare the outcome with the game's.
+// Turns played on the fork, or undone, don't change the game. The fork is in progress,
+// unless it is forked at the end of a game which is over, and takes turns by PlayTurn and
+// PlayShot. It has no time control.
+func (g *Game) Fork(id string, turns int) (*Game, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("fork", InProgress, Finished, Drawn, Abandoned); err != nil {
+		return nil, err
+	}
+
+	// games abandoned before they started have no board to copy.
+	if g.coins == nil {
+		return nil, fmt.Errorf("fork: game was never started: %w", ErrInvalidState)
+	}
+
+	if turns < 0 || turns > len(g.turns) {
+		return nil, fmt.Errorf("fork: invalid turn %d, %d turns are played", turns, len(g.turns))
+	}
+
+	f := g.clone(id, true)
+
+	for len(f.turns) > turns {
+		f.restoreTurn()
+		f.turns = f.turns[:len(f.turns)-1]
+		f.state = InProgress
+		f.outcome = GameOutcome{}
+	}
+
+	return f, nil
+}
+
+// clone returns a copy of the game which shares nothing changed by turns with it. Turns and
+// history are copied only if withHistory is set. It must be called holding lock of the game.
+func (g *Game) clone(id string, withHistory bool) *Game {
+	coins := *g.coins
+	f := &Game{
+		ID:              id,
+		state:           g.state,
+		players:         make([]*Player, 0, len(g.players)),
+		coins:           &coins,
+		playerIDForTurn: g.playerIDForTurn,
+		turnCount:       g.turnCount,
+		handicaps:       g.handicaps,
+		foulPenalties:   g.foulPenalties,
+		foulDues:        g.foulDues,
+		ranking:         g.ranking.copy(),
+		elimination:     g.elimination,
+		turnCap:         g.turnCap,
+		rounds:          g.rounds,
+		capTurn:         g.capTurn,
+		drawBreak:       g.drawBreak.copy(),
+		deciding:        g.deciding,
+		decidingFrom:    g.decidingFrom,
+		forecast:        g.forecast.copy(),
+		outcome:         g.outcome,
+	}
+
+	for _, p := range g.players {
+		copied := p.copy()
+		copied.board = f.coins
+		f.players = append(f.players, &copied)
+	}
+
+	for _, p := range g.eliminated {
+		for i, player := range g.players {
+			if player == p {
+				f.eliminated = append(f.eliminated, f.players[i])
+			}
+		}
+	}
+
+	if !withHistory {
+		return f
+	}
+
+	f.turns = append([]TurnResult(nil), g.turns...)
+	f.history = make([]savedTurn, 0, len(g.history))
+
+	for _, saved := range g.history {
+		players := make([]Player, 0, len(saved.players))
+		for _, p := range saved.players {
+			players = append(players, p.copy())
+		}
+
+		saved.players = players
+		f.history = append(f.history, saved)
+	}
+
+	return f
+}
+
+// Fork forks the game started by NewBoard, see Game.Fork.
+func Fork(id string, turns int) (*Game, error) {
+	return getDefaultGame().Fork(id, turns)
+}
+
+// copy returns a copy of player which shares no foul history with him.
+func (p *Player) copy() Player {
+	copied := *p
+	copied.FoulHistory = append([]Foul(nil), p.FoulHistory...)
+
+	return copied
+}
@@ -0,0 +1,106 @@
+package carrom_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestFork(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	playTurnsOn(t, g,
+		carrom.Input{StrikeCode: 3},
+		carrom.Input{StrikeCode: 5},
+		carrom.Input{StrikeCode: 3},
+	)
+
+	original := g.Snapshot()
+
+	f, err := g.Fork("what-if", 2)
+	if err != nil {
+		t.Fatalf("Fork()= %v, want= nil", err)
+	}
+
+	if s := f.Snapshot(); s.Turn != 2 || s.CurrentPlayer != "p1" || s.Players[0].Points != -1 || len(f.Turns()) != 2 {
+		t.Errorf("Snapshot() of fork= %+v, want= p1 to play turn 3", s)
+	}
+
+	playTurnsOn(t, f,
+		carrom.Input{StrikeCode: 2},
+		carrom.Input{StrikeCode: 5},
+		carrom.Input{StrikeCode: 1, CoinsPocketedCount: carrom.CoinsPocketedCount{Black: 2}},
+		carrom.Input{StrikeCode: 5},
+		carrom.Input{StrikeCode: 0, CoinsPocketedCount: carrom.CoinsPocketedCount{Black: 1}},
+	)
+
+	if o := f.Outcome(); o.Kind != carrom.OutcomeWon || o.Winner != "p1" {
+		t.Errorf("Outcome() of fork= %+v, want= p1 winning", o)
+	}
+
+	if s := g.Snapshot(); s.Turn != original.Turn || s.Coins != original.Coins || s.Players[0] != original.Players[0] || len(g.Turns()) != 3 {
+		t.Errorf("Snapshot() after playing fork= %+v, want= %+v", s, original)
+	}
+
+	if p1 := g.Players()[0]; len(p1.FoulHistory) != 2 {
+		t.Errorf("FoulHistory after playing fork= %+v, want= 2 striker fouls", p1.FoulHistory)
+	}
+
+	for i := 0; i < 7; i++ {
+		if err := f.Undo(); err != nil {
+			t.Fatalf("Undo() of fork= %v, want= nil", err)
+		}
+	}
+
+	if err := f.Undo(); err == nil {
+		t.Errorf("Undo() of fork past its first turn= nil, want= error")
+	}
+
+	if len(g.Turns()) != 3 {
+		t.Errorf("Turns() after undoing fork= %d, want= 3", len(g.Turns()))
+	}
+}
+
+func TestForkFinished(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	playTurnsOn(t, g, breakerWins...)
+
+	f, err := g.Fork("", len(breakerWins))
+	if err != nil || f.State() != carrom.Finished || f.Outcome().Winner != "p1" {
+		t.Fatalf("Fork() at end= %v, want= finished fork", err)
+	}
+
+	f, _ = g.Fork("", len(breakerWins)-1)
+	if o := f.Outcome(); f.State() != carrom.InProgress || o.Kind != carrom.OutcomeOngoing {
+		t.Errorf("Outcome() of fork before last turn= %+v, want= ongoing", o)
+	}
+
+	for _, turns := range []int{-1, len(breakerWins) + 1} {
+		if _, err := g.Fork("", turns); err == nil {
+			t.Errorf("Fork(%d)= nil, want= error", turns)
+		}
+	}
+
+	if _, err := carrom.NewGame("").Fork("", 0); err == nil {
+		t.Errorf("Fork() of game in setup= nil, want= error")
+	}
+}
+
+func TestForkAbandonedInSetup(t *testing.T) {
+	g := carrom.NewGame("")
+	if err := g.AddPlayers([]string{"p1", "p2"}); err != nil {
+		t.Fatalf("AddPlayers()= %v, want= nil", err)
+	}
+
+	if err := g.Abandon(); err != nil {
+		t.Fatalf("Abandon()= %v, want= nil", err)
+	}
+
+	if _, err := g.Fork("f", 0); !errors.Is(err, carrom.ErrInvalidState) {
+		t.Errorf("Fork() of game abandoned in setup= %v, want= %v", err, carrom.ErrInvalidState)
+	}
+}
@@ -0,0 +1,243 @@
+package carrom
+
+import (
+	"fmt"
+
+	l "github.com/sirupsen/logrus"
+)
+
+// FoulType is what a foul was called for.
+type FoulType int
+
+// Fouls detected by the engine.
+const (
+	// FoulStriker is pocketing the striker.
+	FoulStriker FoulType = iota + 1
+	// FoulDefunct is throwing coins off the board.
+	FoulDefunct
+	// FoulNoPocket is not pocketing a coin for 3 successive turns.
+	FoulNoPocket
+	// FoulShotClock is running out of shot clock.
+	FoulShotClock
+)
+
+// Fouls declared by the umpire, see DeclareFoul.
+const (
+	// FoulTouchingCoin is touching a coin with the hand.
+	FoulTouchingCoin FoulType = iota + 101
+	// FoulCrossingBaseline is striking from over the baseline.
+	FoulCrossingBaseline
+	// FoulOutOfTurn is striking when it is not the turn of the player.
+	FoulOutOfTurn
+)
+
+var foulNames = map[FoulType]string{
+	FoulStriker:          "striker",
+	FoulDefunct:          "defunct",
+	FoulNoPocket:         "no pocket",
+	FoulShotClock:        "shot clock",
+	FoulTouchingCoin:     "touching coin",
+	FoulCrossingBaseline: "crossing baseline",
+	FoulOutOfTurn:        "out of turn",
+}
+
+func (f FoulType) String() string {
+	if name, ok := foulNames[f]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("FoulType(%d)", int(f))
+}
+
+// MarshalText encodes foul type by its name.
+func (f FoulType) MarshalText() ([]byte, error) {
+	if _, ok := foulNames[f]; !ok {
+		return nil, fmt.Errorf("invalid foul type %d", int(f))
+	}
+
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText decodes foul type from its name.
+func (f *FoulType) UnmarshalText(text []byte) error {
+	for t, name := range foulNames {
+		if name == string(text) {
+			*f = t
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid foul type %q", text)
+}
+
+func (f FoulType) isDeclared() bool {
+	return f == FoulTouchingCoin || f == FoulCrossingBaseline || f == FoulOutOfTurn
+}
+
+// Foul is a foul of a player. Penalty is the points it took from the player, not counting the
+// point lost for every third foul. Turn is the last turn played when the foul was called.
+type Foul struct {
+	Type    FoulType
+	Turn    int
+	Penalty int
+}
+
+// foulPenalties is points taken for the fouls declared by the umpire in new games.
+var foulPenalties = map[FoulType]int{
+	FoulTouchingCoin:     1,
+	FoulCrossingBaseline: 1,
+	FoulOutOfTurn:        1,
+}
+
+// SetFoulPenalties sets points taken for the fouls declared by the umpire, in the games created
+// after it by NewBoard or NewGame. Penalties of the fouls detected by the engine are set by the rules.
+// Fouls not in penalties take no point, but still count as fouls.
+func SetFoulPenalties(penalties map[FoulType]int) error {
+	copied, err := copyFoulPenalties(penalties)
+	if err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	foulPenalties = copied
+
+	return nil
+}
+
+// SetFoulPenalties sets points taken for the fouls declared by the umpire in a game being set up.
+func (g *Game) SetFoulPenalties(penalties map[FoulType]int) error {
+	copied, err := copyFoulPenalties(penalties)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("set foul penalties", Setup); err != nil {
+		return err
+	}
+
+	g.foulPenalties = copied
+
+	return nil
+}
+
+// foulDues is set if players of new games pay dues for fouls.
+var foulDues bool
+
+// SetFoulDues sets whether players pay a due for every foul in the games created after it by
+// NewBoard or NewGame. A due is a black or white coin the player holds, returned to board.
+// Fouls of players who hold no coin are not carried over.
+func SetFoulDues(dues bool) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	foulDues = dues
+}
+
+// SetFoulDues sets whether players of a game being set up pay a due for every foul.
+func (g *Game) SetFoulDues(dues bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("set foul dues", Setup); err != nil {
+		return err
+	}
+
+	g.foulDues = dues
+
+	return nil
+}
+
+func copyFoulPenalties(penalties map[FoulType]int) (map[FoulType]int, error) {
+	copied := make(map[FoulType]int, len(penalties))
+
+	for f, penalty := range penalties {
+		if !f.isDeclared() || penalty < 0 {
+			return nil, fmt.Errorf("invalid penalty %d for foul %v", penalty, f)
+		}
+
+		copied[f] = penalty
+	}
+
+	return copied, nil
+}
+
+// DeclareFoul takes the penalty of foul f from the player named, and counts it as his foul.
+// It is recorded as a turn of the player, but the turn is not passed. It is taken back by Undo
+// like a turn.
+func (g *Game) DeclareFoul(playerName string, f FoulType) (TurnResult, error) {
+	if !f.isDeclared() {
+		return TurnResult{}, fmt.Errorf("foul %v can't be declared", f)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("declare foul", InProgress); err != nil {
+		return TurnResult{}, err
+	}
+
+	var p *Player
+
+	for _, player := range g.players {
+		if player.PlayerName == playerName {
+			p = player
+		}
+	}
+
+	if p == nil || p.eliminated {
+		return TurnResult{}, fmt.Errorf("player %q is not in game", playerName)
+	}
+
+	g.saveTurn()
+
+	before := *p
+	penalty := g.foulPenalties[f]
+
+	p.Points -= penalty
+	p.foul(f, penalty)
+	g.recordFouls(p, before)
+
+	g.log().WithFields(l.Fields{"player": playerName, "foul": f}).Warnln("foul declared")
+
+	res := TurnResult{
+		Turn:        g.turnCount,
+		PlayerName:  playerName,
+		PointsDelta: p.Points - before.Points,
+		Fouled:      true,
+		Foul:        f,
+		Eliminated:  g.eliminate(p),
+	}
+
+	g.turns = append(g.turns, res)
+
+	if p == g.currentPlayer() && p.eliminated {
+		g.passPlayer()
+	}
+
+	g.checkEnd()
+
+	return res, nil
+}
+
+// DeclareFoul declares foul of the player in the game started by NewBoard.
+func DeclareFoul(playerName string, f FoulType) (TurnResult, error) {
+	return getDefaultGame().DeclareFoul(playerName, f)
+}
+
+// recordFouls sets turn of the fouls p committed since before. p pays a due for every
+// one of them if the game is played with dues.
+func (g *Game) recordFouls(p *Player, before Player) {
+	for i := len(before.FoulHistory); i < len(p.FoulHistory); i++ {
+		p.FoulHistory[i].Turn = g.turnCount
+
+		if g.foulDues && !p.payDue() {
+			g.log().WithField("player", p.PlayerName).Infoln("no coin to pay due")
+		}
+	}
+}
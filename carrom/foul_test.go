@@ -0,0 +1,163 @@
+package carrom_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestDeclareFoul(t *testing.T) {
+	g := carrom.NewGame("")
+	if err := g.SetFoulPenalties(map[carrom.FoulType]int{carrom.FoulCrossingBaseline: 2}); err != nil {
+		t.Fatalf("SetFoulPenalties()= %v, want= nil", err)
+	}
+
+	_ = g.AddPlayers([]string{"p1", "p2"})
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	playTurnsOn(t, g, carrom.Input{0, carrom.CoinsPocketedCount{Black: 1}})
+
+	res, err := g.DeclareFoul("p2", carrom.FoulCrossingBaseline)
+	if err != nil || res.PointsDelta != -2 || !res.Fouled || res.Foul != carrom.FoulCrossingBaseline {
+		t.Errorf("DeclareFoul()= %+v, err: %v, want= foul taking 2 points", res, err)
+	}
+
+	if res, _ := g.DeclareFoul("p1", carrom.FoulTouchingCoin); res.PointsDelta != 0 {
+		t.Errorf("DeclareFoul() without penalty= %+v, want= no point taken", res)
+	}
+
+	expected := []carrom.Foul{{Type: carrom.FoulCrossingBaseline, Turn: 1, Penalty: 2}}
+	if p2 := g.Players()[1]; p2.Points != -2 || !reflect.DeepEqual(p2.FoulHistory, expected) {
+		t.Errorf("Players()[1]= %+v, want= foul history %+v", p2, expected)
+	}
+
+	if res, _ := g.PlayTurn(carrom.Input{StrikeCode: 3}); res.PlayerName != "p2" {
+		t.Errorf("PlayTurn() after declared fouls= played by %q, want= p2", res.PlayerName)
+	}
+
+	expected = append(expected, carrom.Foul{Type: carrom.FoulStriker, Turn: 2, Penalty: 1})
+	if p2 := g.Players()[1]; !reflect.DeepEqual(p2.FoulHistory, expected) {
+		t.Errorf("FoulHistory= %+v, want= %+v", p2.FoulHistory, expected)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := g.Undo(); err != nil {
+			t.Fatalf("Undo()= %v, want= nil", err)
+		}
+	}
+
+	if p2 := g.Players()[1]; p2.Points != -2 || len(p2.FoulHistory) != 1 || len(g.Turns()) != 2 {
+		t.Errorf("Players()[1] after undo= %+v, want= only declared foul", p2)
+	}
+}
+
+func TestDeclareFoulInvalid(t *testing.T) {
+	g := carrom.NewGame("")
+	_ = g.AddPlayers([]string{"p1", "p2"})
+
+	if _, err := g.DeclareFoul("p1", carrom.FoulOutOfTurn); err == nil {
+		t.Errorf("DeclareFoul() before start= nil, want= error")
+	}
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	testCases := []struct {
+		playerName string
+		foul       carrom.FoulType
+	}{
+		{"p1", carrom.FoulStriker},
+		{"p1", carrom.FoulType(0)},
+		{"p3", carrom.FoulOutOfTurn},
+	}
+
+	for _, tc := range testCases {
+		if _, err := g.DeclareFoul(tc.playerName, tc.foul); err == nil {
+			t.Errorf("DeclareFoul(%q, %v)= nil, want= error", tc.playerName, tc.foul)
+		}
+	}
+
+	if len(g.Turns()) != 0 {
+		t.Errorf("Turns() after invalid fouls= %+v, want= none", g.Turns())
+	}
+}
+
+func TestSetFoulPenalties(t *testing.T) {
+	testCases := []struct {
+		penalties   map[carrom.FoulType]int
+		expectedErr bool
+	}{
+		{nil, false},
+		{map[carrom.FoulType]int{carrom.FoulOutOfTurn: 3}, false},
+		{map[carrom.FoulType]int{carrom.FoulDefunct: 3}, true},
+		{map[carrom.FoulType]int{carrom.FoulTouchingCoin: -1}, true},
+	}
+
+	for _, tc := range testCases {
+		if err := carrom.NewGame("").SetFoulPenalties(tc.penalties); (err != nil) != tc.expectedErr {
+			t.Errorf("SetFoulPenalties(%v)= %v, want error: %t", tc.penalties, err, tc.expectedErr)
+		}
+	}
+}
+
+func TestFoulJSON(t *testing.T) {
+	foul := carrom.Foul{Type: carrom.FoulTouchingCoin, Turn: 4, Penalty: 1}
+
+	data, err := json.Marshal(foul)
+	if err != nil || string(data) != `{"Type":"touching coin","Turn":4,"Penalty":1}` {
+		t.Fatalf("Marshal()= %s, err: %v, want= foul type by name", data, err)
+	}
+
+	var actual carrom.Foul
+	if err := json.Unmarshal(data, &actual); err != nil || actual != foul {
+		t.Errorf("Unmarshal()= %+v, err: %v, want= %+v", actual, err, foul)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Type":"spitting"}`), &actual); err == nil {
+		t.Errorf("Unmarshal() of unknown foul type= nil, want= error")
+	}
+}
+
+func TestFoulDues(t *testing.T) {
+	g := carrom.NewGame("")
+	if err := g.SetFoulDues(true); err != nil {
+		t.Fatalf("SetFoulDues()= %v, want= nil", err)
+	}
+
+	_ = g.AddPlayers([]string{"p1", "p2"})
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	playTurnsOn(t, g,
+		carrom.Input{1, carrom.CoinsPocketedCount{Black: 1, White: 1, IsRedPocketed: true}},
+		carrom.Input{StrikeCode: 3},
+	)
+
+	if _, err := g.DeclareFoul("p1", carrom.FoulTouchingCoin); err != nil {
+		t.Fatalf("DeclareFoul()= %v, want= nil", err)
+	}
+
+	playTurnsOn(t, g, carrom.Input{StrikeCode: 3})
+
+	if p1 := g.Players()[0]; p1.Pocketed != (carrom.Coins{Red: 1}) || len(p1.FoulHistory) != 2 {
+		t.Errorf("Pocketed after 2 fouls= %+v, want= only red held", p1.Pocketed)
+	}
+
+	if coins := g.Coins(); coins != (carrom.Coins{Black: 9, White: 9}) {
+		t.Errorf("Coins() after dues= %+v, want= black and white returned", coins)
+	}
+}
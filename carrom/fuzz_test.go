@@ -0,0 +1,56 @@
+//go:build go1.18
+// +build go1.18
+
+package carrom
+
+import (
+	"math/rand"
+	"testing"
+
+	l "github.com/sirupsen/logrus"
+)
+
+func FuzzParseInput(f *testing.F) {
+	for _, s := range []string{"0 b=1", "1 b=2 w=1", "2", "4 red", "5", " 3  ", "1 b=1 b=2", "x", "0 w=-1", "4 red=1"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		c, err := ParseInput(s)
+		if err != nil {
+			return
+		}
+
+		if c.Black < 0 || c.White < 0 {
+			t.Fatalf("ParseInput(%q)= %+v, negative coin count accepted", s, c)
+		}
+
+		again, err := ParseInput(c.String())
+		if err != nil || again != c {
+			t.Fatalf("ParseInput(%q)= %+v, err: %v, want= %+v", c.String(), again, err, c)
+		}
+	})
+}
+
+func FuzzPlayTurn(f *testing.F) {
+	f.Add(int64(1), uint8(2), uint16(40))
+	f.Add(int64(7), uint8(4), uint16(300))
+
+	f.Fuzz(func(t *testing.T, seed int64, players uint8, turns uint16) {
+		l.SetLevel(l.FatalLevel)
+		defer l.SetLevel(l.InfoLevel)
+
+		names := []string{"p1", "p2", "p3", "p4"}[:2+int(players)%3]
+		r := rand.New(rand.NewSource(seed))
+
+		AddPlayersToGame(names)
+		NewBoard()
+
+		m := newModel(len(names))
+		deltas := make(map[string]int, len(names))
+
+		for i := 0; i < int(turns) && defaultGame.getWinner() == nil && !defaultGame.isBoardEmpty(); i++ {
+			checkTurn(t, m, deltas, randomInput(r))
+		}
+	})
+}
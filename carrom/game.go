@@ -0,0 +1,500 @@
+package carrom
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	l "github.com/sirupsen/logrus"
+)
+
+// Game is a carrom board with its players. Turns of a game are played one at a time,
+// while different games can be played at the same time.
+type Game struct {
+	ID string
+
+	mu      sync.Mutex
+	state   State
+	players []*Player
+	coins   *Coins
+	// startCoins is coins the game starts with if it is set up at a position.
+	startCoins      *Coins
+	playerIDForTurn int
+	turnCount       int
+	turns           []TurnResult
+	history         []savedTurn
+	handicaps       map[string]Handicap
+	foulPenalties   map[FoulType]int
+	foulDues        bool
+	ranking         Ranking
+	elimination     Elimination
+	eliminated      []*Player
+	turnCap         TurnCap
+	// rounds counts rounds played and capTurn is the turn the turn cap was reached at.
+	rounds  int
+	capTurn int
+	// drawBreak decides games which would end in draw. deciding is set once the board is
+	// re-racked by it at turn decidingFrom.
+	drawBreak    DrawBreak
+	deciding     bool
+	decidingFrom int
+	timeControl  TimeControl
+	// forecast estimates chances shown in snapshots, estimated once for every position.
+	// position counts changes of position, so chances estimated for an old one are not kept.
+	forecast Forecast
+	chances  map[string]Chances
+	position int
+	clock    *gameClock
+	outcome  GameOutcome
+
+	input chan Input
+	done  chan struct{}
+	// quiet games, eg. games played out by a forecast, don't log or print score board.
+	quiet bool
+}
+
+// quietLog discards logs of quiet games.
+var quietLog = &l.Logger{Out: ioutil.Discard, Formatter: new(l.TextFormatter), Hooks: make(l.LevelHooks), Level: l.PanicLevel}
+
+func (g *Game) log() l.FieldLogger {
+	if g.quiet {
+		return quietLog
+	}
+
+	return l.StandardLogger()
+}
+
+// SetQuiet keeps the game from logging its turns and printing its score board, eg. for games
+// played by the hundred.
+func (g *Game) SetQuiet(quiet bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.quiet = quiet
+}
+
+// NewGame returns a game with the time control and handicaps set for
+// the package by SetTimeControl and SetHandicaps.
+func NewGame(id string) *Game {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	return &Game{
+		ID:            id,
+		handicaps:     handicaps,
+		foulPenalties: foulPenalties,
+		foulDues:      foulDues,
+		ranking:       ranking.copy(),
+		elimination:   elimination,
+		turnCap:       turnCap,
+		drawBreak:     drawBreak.copy(),
+		timeControl:   timeControl,
+		forecast:      forecast.copy(),
+	}
+}
+
+// SetTimeControl sets clocks for the boards started after it by Start.
+func (g *Game) SetTimeControl(tc TimeControl) {
+	if tc.Clock == nil {
+		tc.Clock = realClock{}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.timeControl = tc
+}
+
+// SetHandicaps sets handicap of players added to game after it.
+func (g *Game) SetHandicaps(h map[string]Handicap) error {
+	copied, err := copyHandicaps(h)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.handicaps = copied
+
+	return nil
+}
+
+// AddPlayers sets players of a game being set up. Players play in the order given.
+// Unique player names and more than one player is considered as valid.
+func (g *Game) AddPlayers(playerNames []string) error {
+	if !isValidPlayers(playerNames) {
+		return fmt.Errorf("invalid player names. player names provided: %v", playerNames)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("add players", Setup); err != nil {
+		return err
+	}
+
+	// remove players and position set before if any.
+	g.players = []*Player{}
+	g.startCoins = nil
+	g.playerIDForTurn, g.turnCount, g.rounds = 0, 0, 0
+
+	for _, name := range playerNames {
+		g.players = append(g.players, newPlayer(name, g.handicaps[name]))
+	}
+
+	seat(g.players)
+
+	return nil
+}
+
+// Start puts all coins on board and gives turn to the first player.
+// A channel is returned to feed the input for game. It is read until the game is closed.
+// Input sent after the game is over is not applied, and an error is logged for it.
+func (g *Game) Start() (chan Input, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("start", Setup); err != nil {
+		return nil, err
+	}
+
+	if len(g.players) == 0 {
+		return nil, fmt.Errorf("start: no players are added")
+	}
+
+	g.coins = &Coins{
+		Red:   1,
+		White: 9,
+		Black: 9,
+	}
+
+	if g.startCoins != nil {
+		*g.coins = *g.startCoins
+	}
+
+	for _, p := range g.players {
+		p.board = g.coins
+	}
+
+	g.state = InProgress
+
+	if g.timeControl.isEnabled() {
+		g.clock = newGameClock(g, g.timeControl)
+	}
+
+	g.input = make(chan Input, 1)
+	g.done = make(chan struct{})
+	go g.mapInputToStrike(g.input, g.done)
+
+	return g.input, nil
+}
+
+// Abandon ends a game which is not over.
+func (g *Game) Abandon() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("abandon", Setup, InProgress); err != nil {
+		return err
+	}
+
+	g.state = Abandoned
+	g.outcome = g.decideOutcome(nil, ReasonAbandoned)
+	g.stop()
+
+	g.log().WithField("game", g.ID).Println("Game abandoned.")
+
+	return nil
+}
+
+// Close stops reading input and clocks of the game. A game in progress is abandoned.
+// It is safe to call more than once. Input must not be sent to a closed game.
+func (g *Game) Close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state == InProgress || g.state == Setup {
+		g.state = Abandoned
+		g.outcome = g.decideOutcome(nil, ReasonAbandoned)
+	}
+
+	g.stop()
+
+	// input is read until here, so input sent after the game is over doesn't block.
+	if g.done != nil {
+		close(g.done)
+		g.done = nil
+	}
+}
+
+func (g *Game) stop() {
+	if g.clock != nil {
+		g.clock.stop()
+	}
+
+	g.clock = nil
+}
+
+// IsOver returns true if any player won, game ended in draw or it was abandoned.
+func (g *Game) IsOver() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state == InProgress {
+		g.checkEnd()
+	}
+
+	return g.state.IsOver()
+}
+
+// checkEnd finishes game in progress if a player won, coins are exhausted or time is up.
+func (g *Game) checkEnd() {
+	winner, reason, ended := g.end()
+	if !ended {
+		return
+	}
+
+	g.state = Drawn
+	if winner != nil {
+		g.state = Finished
+	}
+
+	g.outcome = g.decideOutcome(winner, reason)
+	g.newPosition()
+
+	if g.outcome.Kind == OutcomeForfeited {
+		g.log().Printf("\n Player named %q ran out of time and forfeits the game. \n", g.clock.flagged.PlayerName)
+	}
+
+	switch {
+	case winner != nil:
+		g.log().Printf("\n Player named %q won the game by scoring %v points. \n", winner.PlayerName, winner.Points)
+	case reason == ReasonBoardExhausted:
+		g.log().Println("\n Coins exhausted and no players won. Game ends in draw.")
+	case reason == ReasonMatchTimeUp:
+		g.log().Println("\n Time is up and no players won. Game ends in draw.")
+	default:
+		g.log().Printf("\n No players won by %s. Game ends in draw. \n", reason)
+	}
+
+	if g.clock != nil {
+		g.clock.stop()
+	}
+
+	if !g.quiet {
+		printScore(g.outcome.Standings)
+	}
+}
+
+// Winner returns name of the player who won the game, or empty string if nobody has won.
+func (g *Game) Winner() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.state.IsOver() {
+		return ""
+	}
+
+	return g.outcome.Winner
+}
+
+// Players returns a copy of the players of the game in the order they play.
+func (g *Game) Players() []Player {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	players := make([]Player, 0, len(g.players))
+	for _, p := range g.players {
+		players = append(players, p.copy())
+	}
+
+	return players
+}
+
+// Coins returns coins left on board.
+func (g *Game) Coins() Coins {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.coins == nil {
+		return Coins{}
+	}
+
+	return *g.coins
+}
+
+// Turns returns the turns played on the board so far, including turns recorded
+// by the shot clock.
+func (g *Game) Turns() []TurnResult {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]TurnResult(nil), g.turns...)
+}
+
+// getWinner returns the leader if he scored at least 5 points and leads as the victory
+// condition of the game needs by at least 3 points, or the lead his handicap allows.
+// nil is returned if no such player exists.
+func (g *Game) getWinner() *Player {
+	if g.elimination.Enabled {
+		return g.lastStanding()
+	}
+
+	players := g.ranked(nil)
+	leader := players[0]
+
+	if leader.Points < pointsToWin {
+		return nil
+	}
+
+	opposition := players[1].Points
+
+	if g.ranking.Victory == LeadOverAll {
+		opposition = 0
+
+		for _, p := range players[1:] {
+			opposition += p.Points
+		}
+	}
+
+	if leader.Points-opposition >= leader.leadNeeded() {
+		return leader
+	}
+
+	return nil
+}
+
+// boardWinner returns winner of a finished board, including players winning
+// because their opponent ran out of time.
+func (g *Game) boardWinner() *Player {
+	if g.clock.isTimeUp() && g.clock.flagged != nil {
+		return g.ranked(g.clock.flagged)[0]
+	}
+
+	return g.getWinner()
+}
+
+func (g *Game) isBoardEmpty() bool {
+	return g.coins.Red == 0 && g.coins.Black == 0 && g.coins.White == 0
+}
+
+func (g *Game) mapInputToStrike(input chan Input, done chan struct{}) {
+	for {
+		select {
+		case c := <-input:
+			// invalid strikes are logged by the strikes themselves.
+			if _, err := g.PlayTurn(c); errors.Is(err, ErrInvalidState) {
+				g.mu.Lock()
+				g.log().WithError(err).WithField("input", c).Errorln("input ignored")
+				g.mu.Unlock()
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// PlayTurn applies input to the player whose turn it is and waits until it is applied.
+// Turn will be passed to next player only if current player successfully completes
+// his turn by providing valid input. Otherwise an error is returned and the same
+// player has to play again.
+func (g *Game) PlayTurn(c Input) (TurnResult, error) {
+	if err := c.validate(); err != nil {
+		l.WithError(err).Errorln("invalid input. Ignoring request")
+
+		return TurnResult{}, err
+	}
+
+	return g.play([]Input{c}, nil)
+}
+
+// play applies strikes of a turn to the player whose turn it is. Nothing is applied
+// if any of the strikes is invalid.
+func (g *Game) play(strikes []Input, shot *Shot) (TurnResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("play turn", InProgress); err != nil {
+		return TurnResult{}, err
+	}
+
+	if g.clock.isTimeUp() {
+		return TurnResult{}, fmt.Errorf("time is up")
+	}
+
+	p := g.currentPlayer()
+	if err := checkSeat(p, shot); err != nil {
+		return TurnResult{}, err
+	}
+
+	g.saveTurn()
+
+	before := *p
+
+	var err error
+
+	if len(strikes) == 1 {
+		g.log().WithField("input", strikes[0]).Infoln("input received")
+
+		err = p.play(strikes[0])
+	} else {
+		g.log().WithField("strikes", strikes).Infoln("compound shot received")
+
+		err = p.playCompound(strikes)
+	}
+
+	if err != nil {
+		g.restoreTurn()
+
+		return TurnResult{}, err
+	}
+
+	if g.clock != nil {
+		g.clock.turnPlayed()
+	}
+
+	res := g.endTurn(p, before, strikes, shot, false)
+
+	if g.clock != nil {
+		g.clock.turnStarted()
+	}
+
+	g.checkEnd()
+
+	return res, nil
+}
+
+// endTurn records the turn p played and passes turn to next player. shot is nil for turns
+// played by strike code and timeout is set for turns recorded by the shot clock.
+func (g *Game) endTurn(p *Player, before Player, strikes []Input, shot *Shot, timeout bool) TurnResult {
+	g.turnCount++
+	g.recordFouls(p, before)
+	eliminated := g.eliminate(p)
+	last := g.playerIDForTurn
+	g.passPlayer()
+
+	if g.playerIDForTurn <= last {
+		g.rounds++
+	}
+
+	res := TurnResult{
+		Turn:        g.turnCount,
+		PlayerName:  p.PlayerName,
+		Input:       strikes[0],
+		PointsDelta: p.Points - before.Points,
+		Fouled:      p.totalFouls() > before.totalFouls(),
+		Timeout:     timeout,
+		Eliminated:  eliminated,
+		Shot:        shot,
+	}
+
+	if len(strikes) > 1 {
+		res.Strikes = strikes
+	}
+
+	g.turns = append(g.turns, res)
+
+	return res
+}
@@ -0,0 +1,91 @@
+package carrom_test
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+	"time"
+
+	l "github.com/sirupsen/logrus"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestGamesAreIndependent(t *testing.T) {
+	g1, g2 := carrom.NewGame("g1"), carrom.NewGame("g2")
+
+	for g, names := range map[*carrom.Game][]string{g1: {"p1", "p2"}, g2: {"p3", "p4"}} {
+		if err := g.AddPlayers(names); err != nil {
+			t.Fatalf("AddPlayers(%v)= %v, want= nil", names, err)
+		}
+
+		if _, err := g.Start(); err != nil {
+			t.Fatalf("Start()= %v, want= nil", err)
+		}
+	}
+
+	defer g1.Close()
+	defer g2.Close()
+
+	if _, err := g1.PlayTurn(carrom.Input{StrikeCode: 2}); err != nil {
+		t.Fatalf("PlayTurn()= %v, want= nil", err)
+	}
+
+	if res, err := g2.PlayTurn(carrom.Input{StrikeCode: 2}); err != nil || res.PlayerName != "p3" {
+		t.Errorf("PlayTurn() on other game= %+v, err: %v, want= red strike of p3", res, err)
+	}
+
+	if c := g1.Coins(); c.Red != 0 || c.Black != 9 {
+		t.Errorf("Coins()= %+v, want= no red and 9 black", c)
+	}
+}
+
+func TestGameCloseStopsInput(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 10; i++ {
+		g := carrom.NewGame("")
+		g.AddPlayers([]string{"p1", "p2"})
+		g.Start()
+		g.Close()
+		g.Close()
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines after Close()= %d, want= %d", after, before)
+	}
+}
+
+func TestQuietGame(t *testing.T) {
+	var logged bytes.Buffer
+
+	out := l.StandardLogger().Out
+	l.SetOutput(&logged)
+
+	defer l.SetOutput(out)
+
+	g, input := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	g.SetQuiet(true)
+
+	if _, err := g.DeclareFoul("p2", carrom.FoulTouchingCoin); err != nil {
+		t.Fatalf("DeclareFoul()= %v, want= nil", err)
+	}
+
+	playTurnsOn(t, g, win...)
+
+	// input is read one at a time, so the first is ignored once the third is sent.
+	for i := 0; i < 3; i++ {
+		input <- carrom.Input{StrikeCode: 5}
+	}
+
+	if logged.Len() != 0 {
+		t.Errorf("quiet game logged %q, want= nothing", logged.String())
+	}
+}
@@ -0,0 +1,144 @@
+package carrom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	pointsToWin  = 5
+	leadToWin    = 3
+	foulsAllowed = 3
+	redPoints    = 3
+)
+
+// Handicap gives a weaker player an advantage over the rules of the game.
+type Handicap struct {
+	// StartingPoints is points player has when board starts.
+	StartingPoints int
+	// LeadReduction is taken from the 3 points lead player needs to win. Lead needed is at least 1.
+	LeadReduction int
+	// ExtraFouls is number of fouls allowed over 3 before player loses a point for fouls.
+	ExtraFouls int
+	// RedBonus is added to points of a red strike.
+	RedBonus int
+}
+
+func (h Handicap) validate() error {
+	if h.LeadReduction < 0 || h.LeadReduction >= leadToWin || h.ExtraFouls < 0 || h.RedBonus < 0 ||
+		h.StartingPoints < 0 || h.StartingPoints >= pointsToWin {
+		return fmt.Errorf("invalid handicap %+v", h)
+	}
+
+	return nil
+}
+
+// String returns handicap as shown on the score board.
+func (h Handicap) String() string {
+	var parts []string
+
+	if h.StartingPoints != 0 {
+		parts = append(parts, fmt.Sprintf("+%d start", h.StartingPoints))
+	}
+
+	if h.LeadReduction != 0 {
+		parts = append(parts, fmt.Sprintf("lead %d", leadToWin-h.LeadReduction))
+	}
+
+	if h.ExtraFouls != 0 {
+		parts = append(parts, fmt.Sprintf("fouls %d", foulsAllowed+h.ExtraFouls))
+	}
+
+	if h.RedBonus != 0 {
+		parts = append(parts, fmt.Sprintf("red +%d", h.RedBonus))
+	}
+
+	if len(parts) == 0 {
+		return "-"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// configMu guards the time control and handicaps new games start with.
+var configMu sync.Mutex
+
+// handicaps is applied to players by name when they are added to a game.
+var handicaps map[string]Handicap
+
+// SetHandicaps sets handicap of players for games they are added to after it.
+// Players not in h play without handicap. Pass nil to remove all handicaps.
+func SetHandicaps(h map[string]Handicap) error {
+	copied, err := copyHandicaps(h)
+	if err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	handicaps = copied
+
+	return nil
+}
+
+func copyHandicaps(h map[string]Handicap) (map[string]Handicap, error) {
+	copied := make(map[string]Handicap, len(h))
+
+	for name, handicap := range h {
+		if err := handicap.validate(); err != nil {
+			return nil, fmt.Errorf("player %q: %w", name, err)
+		}
+
+		copied[name] = handicap
+	}
+
+	return copied, nil
+}
+
+// HandicapsFromRatings gives handicap to every player rated below the best rated player.
+// A player gets a starting point for every 100 rating points below the best player,
+// up to 4 points. Players 300 or more below also need 1 point less of lead to win and
+// are allowed one more foul.
+func HandicapsFromRatings(ratings map[string]int) map[string]Handicap {
+	names := make([]string, 0, len(ratings))
+	for name := range ratings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	best := 0
+	for i, name := range names {
+		if i == 0 || ratings[name] > best {
+			best = ratings[name]
+		}
+	}
+
+	h := make(map[string]Handicap, len(ratings))
+
+	for _, name := range names {
+		gap := best - ratings[name]
+
+		handicap := Handicap{StartingPoints: gap / 100}
+		if handicap.StartingPoints > pointsToWin-1 {
+			handicap.StartingPoints = pointsToWin - 1
+		}
+
+		if gap >= 300 {
+			handicap.LeadReduction = 1
+			handicap.ExtraFouls = 1
+		}
+
+		h[name] = handicap
+	}
+
+	return h
+}
+
+// leadNeeded returns lead over other players p needs to win.
+func (p *Player) leadNeeded() int {
+	return leadToWin - p.Handicap.LeadReduction
+}
@@ -0,0 +1,82 @@
+package carrom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHandicapWinner(t *testing.T) {
+	defer SetHandicaps(nil)
+
+	testCases := []struct {
+		handicaps      map[string]Handicap
+		inputs         []Input
+		expectedWinner string
+	}{
+		{nil, []Input{{StrikeCode: 5}, {1, CoinsPocketedCount{Black: 2}}}, ""},
+		{map[string]Handicap{"p2": {StartingPoints: 3}}, []Input{{StrikeCode: 5}, {1, CoinsPocketedCount{Black: 2}}}, "p2"},
+		{nil, []Input{{StrikeCode: 2}, {1, CoinsPocketedCount{Black: 2}}, {0, CoinsPocketedCount{Black: 1}}, {0, CoinsPocketedCount{Black: 1}}, {0, CoinsPocketedCount{Black: 1}}}, ""},
+		{map[string]Handicap{"p1": {LeadReduction: 1}}, []Input{{StrikeCode: 2}, {1, CoinsPocketedCount{Black: 2}}, {0, CoinsPocketedCount{Black: 1}}, {0, CoinsPocketedCount{Black: 1}}, {0, CoinsPocketedCount{Black: 1}}}, "p1"},
+		{map[string]Handicap{"p2": {RedBonus: 2}}, []Input{{StrikeCode: 5}, {StrikeCode: 2}}, "p2"},
+	}
+
+	for _, tc := range testCases {
+		if err := SetHandicaps(tc.handicaps); err != nil {
+			t.Fatalf("SetHandicaps(%v)= %v, want= nil", tc.handicaps, err)
+		}
+
+		AddPlayersToGame([]string{"p1", "p2"})
+		NewBoard()
+
+		for _, c := range tc.inputs {
+			if _, err := PlayTurn(c); err != nil {
+				t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+			}
+		}
+
+		winner := ""
+		if p := defaultGame.getWinner(); p != nil {
+			winner = p.PlayerName
+		}
+
+		if winner != tc.expectedWinner {
+			t.Errorf("getWinner() with handicaps %v= %q, want= %q", tc.handicaps, winner, tc.expectedWinner)
+		}
+	}
+}
+
+func TestHandicapExtraFouls(t *testing.T) {
+	p := &Player{PlayerName: "p1", Handicap: Handicap{ExtraFouls: 1}}
+
+	for i, expected := range []int{-1, -2, -3, -5, -6} {
+		p.StrikerStrike()
+
+		if p.Points != expected {
+			t.Errorf("StrikerStrike() x %d= score: %d, want= score: %d", i+1, p.Points, expected)
+		}
+	}
+}
+
+func TestSetHandicapsInvalid(t *testing.T) {
+	defer SetHandicaps(nil)
+
+	for _, h := range []Handicap{{StartingPoints: 5}, {LeadReduction: 3}, {ExtraFouls: -1}, {RedBonus: -1}} {
+		if err := SetHandicaps(map[string]Handicap{"p1": h}); err == nil {
+			t.Errorf("SetHandicaps(%+v)= nil, want= error", h)
+		}
+	}
+}
+
+func TestHandicapsFromRatings(t *testing.T) {
+	actual := HandicapsFromRatings(map[string]int{"a": 1500, "b": 1290, "c": 1100, "d": 500})
+	expected := map[string]Handicap{
+		"a": {},
+		"b": {StartingPoints: 2},
+		"c": {StartingPoints: 4, LeadReduction: 1, ExtraFouls: 1},
+		"d": {StartingPoints: 4, LeadReduction: 1, ExtraFouls: 1},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("HandicapsFromRatings()= %v, want= %v", actual, expected)
+	}
+}
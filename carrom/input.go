@@ -0,0 +1,87 @@
+package carrom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns input in the notation accepted by ParseInput.
+func (c Input) String() string {
+	fields := []string{strconv.Itoa(c.StrikeCode)}
+
+	if c.Black != 0 {
+		fields = append(fields, "b="+strconv.Itoa(c.Black))
+	}
+
+	if c.White != 0 {
+		fields = append(fields, "w="+strconv.Itoa(c.White))
+	}
+
+	if c.IsRedPocketed {
+		fields = append(fields, "red")
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// ParseInput reads a turn written as strike code followed by the pocketed coins, e.g.
+//
+//	1 b=2 w=1
+//	4 red
+//
+// Strike code is mandatory and must come first. b and w take the count of black and
+// white coins and red marks red coin as pocketed. Each of them can be given once.
+func ParseInput(s string) (Input, error) {
+	var c Input
+
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return c, fmt.Errorf("empty input")
+	}
+
+	code, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return c, fmt.Errorf("invalid strike code %q", fields[0])
+	}
+
+	c.StrikeCode = code
+	seen := make(map[string]struct{}, 0)
+
+	for _, f := range fields[1:] {
+		key, value := f, ""
+		if i := strings.IndexByte(f, '='); i >= 0 {
+			key, value = f[:i], f[i+1:]
+		}
+
+		if _, ok := seen[key]; ok {
+			return c, fmt.Errorf("%q given more than once", key)
+		}
+
+		seen[key] = struct{}{}
+
+		switch key {
+		case "b", "w":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 0 {
+				return c, fmt.Errorf("invalid coin count %q", f)
+			}
+
+			if key == "b" {
+				c.Black = count
+			} else {
+				c.White = count
+			}
+		case red:
+			if value != "" {
+				return c, fmt.Errorf("red takes no value, got %q", f)
+			}
+
+			c.IsRedPocketed = true
+		default:
+			return c, fmt.Errorf("unknown field %q", f)
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,243 @@
+package carrom
+
+import (
+	"fmt"
+
+	l "github.com/sirupsen/logrus"
+)
+
+// MatchFormat decides when a match of several boards is over. Exactly one field must be set.
+type MatchFormat struct {
+	// BestOf is won by the first player to win more than half of BestOf boards.
+	// If all boards are played without that, player with most board wins takes the match.
+	BestOf int
+	// TargetPoints is won by the first player whose points over all boards reach it.
+	TargetPoints int
+	// Boards is a fixed number of boards. Player with most board wins takes the match.
+	Boards int
+}
+
+func (f MatchFormat) validate() error {
+	set := 0
+
+	for _, v := range []int{f.BestOf, f.TargetPoints, f.Boards} {
+		if v < 0 {
+			return fmt.Errorf("invalid match format %+v", f)
+		}
+
+		if v > 0 {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return fmt.Errorf("exactly one of BestOf, TargetPoints and Boards must be set. got %+v", f)
+	}
+
+	return nil
+}
+
+// BoardResult is how a board of a match ended. Winner is empty for a drawn board.
+type BoardResult struct {
+	Board   int
+	Breaker string
+	Winner  string
+	Points  map[string]int
+	Outcome GameOutcome
+}
+
+// Match chains boards between the same players. Player breaking the board is rotated
+// between boards, so every board is started by the player next to the previous breaker.
+type Match struct {
+	Format    MatchFormat
+	Boards    []BoardResult
+	BoardWins map[string]int
+	Points    map[string]int
+
+	playerNames  []string
+	board        *Game
+	boardRunning bool
+	winner       string
+	over         bool
+}
+
+// NewMatch returns a match between players in the given format.
+// Player names are validated the same way as in AddPlayersToGame.
+func NewMatch(playerNames []string, format MatchFormat) (*Match, error) {
+	if !isValidPlayers(playerNames) {
+		return nil, fmt.Errorf("invalid player names. player names provided: %v", playerNames)
+	}
+
+	if err := format.validate(); err != nil {
+		return nil, err
+	}
+
+	m := &Match{
+		Format:      format,
+		BoardWins:   make(map[string]int, len(playerNames)),
+		Points:      make(map[string]int, len(playerNames)),
+		playerNames: append([]string(nil), playerNames...),
+	}
+
+	for _, name := range playerNames {
+		m.BoardWins[name] = 0
+		m.Points[name] = 0
+	}
+
+	return m, nil
+}
+
+// NextBoard starts a game of its own for the next board of the match.
+// The returned channel feeds input of the board, see Game.Start.
+func (m *Match) NextBoard() (chan Input, error) {
+	if m.over {
+		return nil, fmt.Errorf("match is over")
+	}
+
+	if m.boardRunning {
+		return nil, fmt.Errorf("board %d is not over", len(m.Boards)+1)
+	}
+
+	breaker := len(m.Boards) % len(m.playerNames)
+	order := append(append([]string(nil), m.playerNames[breaker:]...), m.playerNames[:breaker]...)
+
+	g := NewGame(fmt.Sprintf("board-%d", len(m.Boards)+1))
+	if err := g.AddPlayers(order); err != nil {
+		return nil, err
+	}
+
+	input, err := g.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	m.board = g
+	m.boardRunning = true
+
+	l.WithFields(l.Fields{"board": len(m.Boards) + 1, "breaker": order[0]}).Println("board started")
+
+	return input, nil
+}
+
+// Board returns game of the current board, or of the last one once it is over.
+// It is nil until the first board is started.
+func (m *Match) Board() *Game {
+	return m.board
+}
+
+// IsBoardOver returns true once current board is over, and adds its result to the match.
+func (m *Match) IsBoardOver() bool {
+	if !m.boardRunning {
+		return true
+	}
+
+	if !m.board.IsOver() {
+		return false
+	}
+
+	outcome := m.board.Outcome()
+	result := BoardResult{
+		Board:   len(m.Boards) + 1,
+		Breaker: m.board.Players()[0].PlayerName,
+		Winner:  outcome.Winner,
+		Points:  make(map[string]int, len(outcome.Standings)),
+		Outcome: outcome,
+	}
+
+	for _, s := range outcome.Standings {
+		result.Points[s.PlayerName] = s.Points
+	}
+
+	m.board.Close()
+	m.boardRunning = false
+	m.addResult(result)
+
+	return true
+}
+
+// IsOver returns true if match is decided or ended in draw.
+func (m *Match) IsOver() bool {
+	return m.over
+}
+
+// Winner returns name of the player who won the match.
+// It is empty while match is running or if it ended in draw.
+func (m *Match) Winner() string {
+	return m.winner
+}
+
+func (m *Match) addResult(result BoardResult) {
+	m.Boards = append(m.Boards, result)
+
+	if result.Winner != "" {
+		m.BoardWins[result.Winner]++
+	}
+
+	for name, points := range result.Points {
+		m.Points[name] += points
+	}
+
+	switch {
+	case m.Format.BestOf > 0:
+		if leader := m.leader(m.BoardWins, m.Points); m.BoardWins[leader]*2 > m.Format.BestOf {
+			m.end(leader)
+		} else if len(m.Boards) >= m.Format.BestOf {
+			m.end(leader)
+		}
+	case m.Format.TargetPoints > 0:
+		if leader := m.leader(m.Points, m.BoardWins); m.Points[leader] >= m.Format.TargetPoints {
+			m.end(leader)
+		}
+	case len(m.Boards) >= m.Format.Boards:
+		m.end(m.leader(m.BoardWins, m.Points))
+	}
+
+	if m.over {
+		printMatchScore(m)
+	}
+}
+
+func (m *Match) end(winner string) {
+	m.over = true
+	m.winner = winner
+
+	if winner == "" {
+		l.Println("\n Match ends in draw.")
+
+		return
+	}
+
+	l.Printf("\n Player named %q won the match. \n", winner)
+}
+
+// leader returns player who is ahead by score and, on a tie, by tieBreak.
+// Empty string is returned if players can't be separated.
+func (m *Match) leader(score, tieBreak map[string]int) string {
+	leader := ""
+	tied := false
+
+	for _, name := range m.playerNames {
+		switch {
+		case leader == "" || score[name] > score[leader] ||
+			(score[name] == score[leader] && tieBreak[name] > tieBreak[leader]):
+			leader = name
+			tied = false
+		case score[name] == score[leader] && tieBreak[name] == tieBreak[leader]:
+			tied = true
+		}
+	}
+
+	if tied {
+		return ""
+	}
+
+	return leader
+}
+
+func printMatchScore(m *Match) {
+	fmt.Printf("\n Match score \n -----------------------------------  \n | Player Name | Boards won | Points | \n ----------------------------------- \n")
+
+	for _, name := range m.playerNames {
+		fmt.Printf(" | %-11v | %-10v | %-6v | \n", name, m.BoardWins[name], m.Points[name])
+	}
+}
@@ -0,0 +1,125 @@
+package carrom_test
+
+import (
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// breakerWins makes the player breaking the board win it by 5 points.
+var breakerWins = []carrom.Input{
+	{StrikeCode: 2},
+	{StrikeCode: 5},
+	{1, carrom.CoinsPocketedCount{Black: 2}},
+}
+
+// drawnBoard clears the board without anyone reaching 5 points.
+var drawnBoard = []carrom.Input{
+	{1, carrom.CoinsPocketedCount{Black: 9, IsRedPocketed: true}},
+	{1, carrom.CoinsPocketedCount{White: 9}},
+}
+
+func playBoard(t *testing.T, m *carrom.Match, inputs []carrom.Input) {
+	t.Helper()
+
+	if _, err := m.NextBoard(); err != nil {
+		t.Fatalf("NextBoard()= %v, want= nil", err)
+	}
+
+	playTurnsOn(t, m.Board(), inputs...)
+
+	if !m.IsBoardOver() {
+		t.Fatalf("IsBoardOver()= false, want= true")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		format         carrom.MatchFormat
+		boards         [][]carrom.Input
+		expectedWinner string
+		expectedOver   bool
+	}{
+		{carrom.MatchFormat{BestOf: 3}, [][]carrom.Input{breakerWins, breakerWins}, "", false},
+		{carrom.MatchFormat{BestOf: 3}, [][]carrom.Input{breakerWins, drawnBoard, breakerWins}, "p1", true},
+		{carrom.MatchFormat{BestOf: 3}, [][]carrom.Input{breakerWins, breakerWins, drawnBoard}, "", true},
+		{carrom.MatchFormat{TargetPoints: 10}, [][]carrom.Input{breakerWins, breakerWins, drawnBoard}, "", false},
+		{carrom.MatchFormat{TargetPoints: 10}, [][]carrom.Input{breakerWins, breakerWins, breakerWins}, "p1", true},
+		{carrom.MatchFormat{Boards: 2}, [][]carrom.Input{breakerWins, drawnBoard}, "p1", true},
+	}
+
+	for _, tc := range testCases {
+		m, err := carrom.NewMatch([]string{"p1", "p2"}, tc.format)
+		if err != nil {
+			t.Fatalf("NewMatch(%+v)= %v, want= nil", tc.format, err)
+		}
+
+		for _, board := range tc.boards {
+			playBoard(t, m, board)
+		}
+
+		if m.IsOver() != tc.expectedOver || m.Winner() != tc.expectedWinner {
+			t.Errorf("Match(%+v)= over: %t, winner: %q, want= over: %t, winner: %q",
+				tc.format, m.IsOver(), m.Winner(), tc.expectedOver, tc.expectedWinner)
+		}
+	}
+}
+
+func TestMatchRotatesBreaker(t *testing.T) {
+	m, _ := carrom.NewMatch([]string{"p1", "p2", "p3"}, carrom.MatchFormat{Boards: 4})
+
+	for i := 0; i < 4; i++ {
+		playBoard(t, m, drawnBoard)
+	}
+
+	for i, expected := range []string{"p1", "p2", "p3", "p1"} {
+		if m.Boards[i].Breaker != expected {
+			t.Errorf("Boards[%d].Breaker= %s, want= %s", i, m.Boards[i].Breaker, expected)
+		}
+	}
+
+	if _, err := m.NextBoard(); err == nil {
+		t.Errorf("NextBoard() after match is over= nil, want= error")
+	}
+}
+
+func TestMatchBoardsAreGamesOfTheirOwn(t *testing.T) {
+	m, _ := carrom.NewMatch([]string{"p1", "p2"}, carrom.MatchFormat{Boards: 2})
+
+	if _, err := m.NextBoard(); err != nil {
+		t.Fatalf("NextBoard()= %v, want= nil", err)
+	}
+
+	first := m.Board()
+
+	// the default game is not the board of the match.
+	carrom.AddPlayersToGame([]string{"p3", "p4"})
+	carrom.NewBoard()
+	playTurns(breakerWins...)
+
+	if m.IsBoardOver() {
+		t.Fatalf("IsBoardOver() after default game is over= true, want= false")
+	}
+
+	playTurnsOn(t, first, breakerWins...)
+
+	if !m.IsBoardOver() || m.Boards[0].Winner != "p1" {
+		t.Fatalf("IsBoardOver()= %+v, want= board won by p1", m.Boards)
+	}
+
+	if _, err := m.NextBoard(); err != nil {
+		t.Fatalf("NextBoard()= %v, want= nil", err)
+	}
+
+	if m.Board() == first || m.Board().Players()[0].PlayerName != "p2" {
+		t.Errorf("Board()= %+v, want= a new game broken by p2", m.Board().Players())
+	}
+}
+
+func TestNewMatchInvalidFormat(t *testing.T) {
+	for _, format := range []carrom.MatchFormat{{}, {BestOf: 3, Boards: 2}, {Boards: -1}} {
+		if _, err := carrom.NewMatch([]string{"p1", "p2"}, format); err == nil {
+			t.Errorf("NewMatch(%+v)= nil, want= error", format)
+		}
+	}
+}
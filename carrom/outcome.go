@@ -0,0 +1,205 @@
+package carrom
+
+import (
+	"fmt"
+)
+
+// OutcomeKind is how a game ended, or OutcomeOngoing for a game which is not over.
+type OutcomeKind int
+
+const (
+	// OutcomeOngoing is for games being set up or in progress.
+	OutcomeOngoing OutcomeKind = iota
+	// OutcomeWon is for games with a winner by points.
+	OutcomeWon
+	// OutcomeDrawn is for games ended without a winner.
+	OutcomeDrawn
+	// OutcomeAbandoned is for games left before they were over.
+	OutcomeAbandoned
+	// OutcomeForfeited is for games won because a player ran out of time.
+	OutcomeForfeited
+)
+
+var outcomeNames = map[OutcomeKind]string{
+	OutcomeOngoing:   "ongoing",
+	OutcomeWon:       "won",
+	OutcomeDrawn:     "drawn",
+	OutcomeAbandoned: "abandoned",
+	OutcomeForfeited: "forfeited",
+}
+
+func (k OutcomeKind) String() string {
+	if name, ok := outcomeNames[k]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("OutcomeKind(%d)", int(k))
+}
+
+// Reasons a game ended for.
+const (
+	ReasonLeadReached    = "lead reached"
+	ReasonBoardExhausted = "board exhausted"
+	ReasonMatchTimeUp    = "match time up"
+	ReasonOutOfTime      = "out of time"
+	ReasonAbandoned      = "abandoned"
+	ReasonLastStanding   = "last player standing"
+	ReasonTurnCap        = "turn cap reached"
+	ReasonSuddenDeath    = "sudden death"
+	ReasonShootout       = "shootout"
+	ReasonTieBreak       = "tie break"
+)
+
+// Standing is the place of a player at the end of a game. Players tied after
+// the tie breakers of the game share the place.
+type Standing struct {
+	Place       int
+	PlayerName  string
+	Points      int
+	Fouls       int
+	FoulHistory []Foul
+	RedPocketed int
+	// Pocketed is coins the player holds.
+	Pocketed   Coins
+	Handicap   Handicap
+	Eliminated bool
+}
+
+// GameOutcome is the result of a game. Standings are ordered by place, see Ranking,
+// with players who ran out of time last. Margin is points of the winner over the best
+// of the other players, which can be negative for forfeited games.
+type GameOutcome struct {
+	Kind      OutcomeKind
+	Winner    string
+	Standings []Standing
+	Margin    int
+	Reason    string
+}
+
+// IsOver returns true for outcomes of games which are over.
+func (o GameOutcome) IsOver() bool {
+	return o.Kind != OutcomeOngoing
+}
+
+// Outcome returns the outcome of the game. Standings of an ongoing game are the current ones.
+func (g *Game) Outcome() GameOutcome {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state.IsOver() {
+		return g.outcome
+	}
+
+	return GameOutcome{Kind: OutcomeOngoing, Standings: g.standings(nil)}
+}
+
+// decideOutcome returns the outcome of a game which ended for reason. winner is nil for
+// games which were drawn or abandoned. It must be called holding lock of the game.
+func (g *Game) decideOutcome(winner *Player, reason string) GameOutcome {
+	var flagged *Player
+	if g.clock.isTimeUp() {
+		flagged = g.clock.flagged
+	}
+
+	o := GameOutcome{Kind: OutcomeWon, Standings: g.standings(flagged), Reason: reason}
+
+	switch {
+	case reason == ReasonAbandoned:
+		o.Kind = OutcomeAbandoned
+
+		return o
+	case winner == nil:
+		o.Kind = OutcomeDrawn
+
+		return o
+	case reason == ReasonOutOfTime:
+		o.Kind = OutcomeForfeited
+	}
+
+	o.Winner = winner.PlayerName
+	o.Margin = winner.Points - gethighestScore(g.otherPlayers(winner)...).Points
+
+	return o
+}
+
+// end returns whether the game in progress is over, its winner and the reason it ended for.
+func (g *Game) end() (winner *Player, reason string, ended bool) {
+	if g.clock.isTimeUp() && g.clock.flagged != nil {
+		return g.boardWinner(), ReasonOutOfTime, true
+	}
+
+	if winner := g.getWinner(); winner != nil {
+		return winner, g.winReason(), true
+	}
+
+	switch {
+	case g.clock.isTimeUp():
+		reason, ended = ReasonMatchTimeUp, true
+	case g.deciding:
+		winner, reason, ended = g.decide()
+	case g.isBoardEmpty():
+		reason, ended = ReasonBoardExhausted, true
+	default:
+		winner, ended = g.adjudicate()
+		reason = g.turnCap.reason()
+	}
+
+	if !ended || winner != nil || g.drawBreak.Procedure == AllowDraws {
+		return winner, reason, ended
+	}
+
+	return g.breakDraw(reason)
+}
+
+func (g *Game) winReason() string {
+	switch {
+	case len(g.ranked(nil)) == 1:
+		return ReasonLastStanding
+	case g.elimination.Enabled:
+		return ReasonBoardExhausted
+	default:
+		return ReasonLeadReached
+	}
+}
+
+// standings returns players placed by ranking of the game, followed by eliminated players
+// in the reverse order they were knocked out. flagged player, if any, is placed last.
+func (g *Game) standings(flagged *Player) []Standing {
+	players := g.ranked(flagged)
+	standings := make([]Standing, 0, len(g.players))
+
+	for i, p := range players {
+		place := i + 1
+		if i > 0 && g.ranking.compare(players[i-1], p) == 0 {
+			place = standings[i-1].Place
+		}
+
+		standings = append(standings, newStanding(p, place))
+	}
+
+	for i := len(g.eliminated) - 1; i >= 0; i-- {
+		if p := g.eliminated[i]; p != flagged {
+			standings = append(standings, newStanding(p, len(standings)+1))
+		}
+	}
+
+	if flagged != nil {
+		standings = append(standings, newStanding(flagged, len(g.players)))
+	}
+
+	return standings
+}
+
+func newStanding(p *Player, place int) Standing {
+	return Standing{
+		Place:       place,
+		PlayerName:  p.PlayerName,
+		Points:      p.Points,
+		Fouls:       p.totalFouls(),
+		FoulHistory: append([]Foul(nil), p.FoulHistory...),
+		RedPocketed: p.redPocketed,
+		Pocketed:    p.Pocketed,
+		Handicap:    p.Handicap,
+		Eliminated:  p.eliminated,
+	}
+}
@@ -0,0 +1,104 @@
+package carrom_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestOutcome(t *testing.T) {
+	testCases := []struct {
+		inputs   []carrom.Input
+		abandon  bool
+		expected carrom.GameOutcome
+	}{
+		{
+			breakerWins[:1], false,
+			carrom.GameOutcome{Kind: carrom.OutcomeOngoing, Standings: []carrom.Standing{{Place: 1, PlayerName: "p1", Points: 3, RedPocketed: 1, Pocketed: carrom.Coins{Red: 1}}, {Place: 2, PlayerName: "p2"}}},
+		},
+		{
+			breakerWins, false,
+			carrom.GameOutcome{Kind: carrom.OutcomeWon, Winner: "p1", Margin: 5, Reason: carrom.ReasonLeadReached,
+				Standings: []carrom.Standing{{Place: 1, PlayerName: "p1", Points: 5, RedPocketed: 1, Pocketed: carrom.Coins{Red: 1, Black: 2}}, {Place: 2, PlayerName: "p2"}}},
+		},
+		{
+			[]carrom.Input{{StrikeCode: 3}, {StrikeCode: 2}}, false,
+			carrom.GameOutcome{Kind: carrom.OutcomeOngoing, Standings: []carrom.Standing{{Place: 1, PlayerName: "p2", Points: 3, RedPocketed: 1, Pocketed: carrom.Coins{Red: 1}}, {Place: 2, PlayerName: "p1", Points: -1, Fouls: 1,
+				FoulHistory: []carrom.Foul{{Type: carrom.FoulStriker, Turn: 1, Penalty: 1}}}}},
+		},
+		{
+			drawnBoard, false,
+			carrom.GameOutcome{Kind: carrom.OutcomeDrawn, Reason: carrom.ReasonBoardExhausted,
+				Standings: []carrom.Standing{{Place: 1, PlayerName: "p1", Points: 2, RedPocketed: 1, Pocketed: carrom.Coins{Red: 1, Black: 9}},
+					{Place: 1, PlayerName: "p2", Points: 2, Pocketed: carrom.Coins{White: 9}}}},
+		},
+		{
+			breakerWins[:1], true,
+			carrom.GameOutcome{Kind: carrom.OutcomeAbandoned, Reason: carrom.ReasonAbandoned,
+				Standings: []carrom.Standing{{Place: 1, PlayerName: "p1", Points: 3, RedPocketed: 1, Pocketed: carrom.Coins{Red: 1}}, {Place: 2, PlayerName: "p2"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		g, _ := startGame(t, "p1", "p2")
+
+		for _, c := range tc.inputs {
+			if _, err := g.PlayTurn(c); err != nil {
+				t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+			}
+		}
+
+		if tc.abandon {
+			g.Close()
+		}
+
+		if actual := g.Outcome(); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("Outcome() after %v= %+v, want= %+v", tc.inputs, actual, tc.expected)
+		}
+
+		g.Close()
+	}
+}
+
+func TestOutcomeForfeited(t *testing.T) {
+	clock := carrom.NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	g := carrom.NewGame("")
+	g.SetTimeControl(carrom.TimeControl{PlayerTime: time.Minute, Clock: clock})
+
+	if err := g.AddPlayers([]string{"p1", "p2", "p3"}); err != nil {
+		t.Fatalf("AddPlayers()= %v, want= nil", err)
+	}
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	for _, c := range []carrom.Input{{StrikeCode: 2}, {StrikeCode: 0, CoinsPocketedCount: carrom.CoinsPocketedCount{White: 1}}} {
+		if _, err := g.PlayTurn(c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	clock.Advance(time.Minute)
+
+	expected := carrom.GameOutcome{
+		Kind:   carrom.OutcomeForfeited,
+		Winner: "p1",
+		Margin: 2,
+		Reason: carrom.ReasonOutOfTime,
+		Standings: []carrom.Standing{
+			{Place: 1, PlayerName: "p1", Points: 3, RedPocketed: 1, Pocketed: carrom.Coins{Red: 1}}, {Place: 2, PlayerName: "p2", Points: 1, Pocketed: carrom.Coins{White: 1}}, {Place: 3, PlayerName: "p3"},
+		},
+	}
+
+	g.Close()
+
+	if actual := g.Outcome(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Outcome()= %+v, want= %+v", actual, expected)
+	}
+}
@@ -1,37 +1,73 @@
 package carrom
 
-var playersOnBoard []*Player
-var playerIDForTurn int
-
 // Player is details of the player in game.
 type Player struct {
 	PlayerName    string
 	Points        int
 	FoulCount     int
 	NoPocketCount int
+	Handicap      Handicap
+	// Seat is the side player sits at.
+	Seat Side `json:",omitempty"`
+	// FoulHistory unlike FoulCount is never reset during a game.
+	FoulHistory []Foul
+	// Pocketed is coins pocketed by player which he holds, ie. not returned to board.
+	Pocketed Coins
+
+	// redPocketed counts red coins pocketed by player.
+	redPocketed int
+	// eliminated players are out of the rotation of turns, see Elimination.
+	eliminated bool
+	// board is coins of the game player is in. Players not added to a game use CoinsOnBoard.
+	board *Coins
 }
 
-func newPlayer(name string) *Player {
+func newPlayer(name string, h Handicap) *Player {
 	return &Player{
 		PlayerName: name,
+		Points:     h.StartingPoints,
+		Handicap:   h,
+	}
+}
+
+// pocket moves count coins of the colour from board to the coins player holds.
+func (p *Player) pocket(coinColor string, count int) {
+	p.Pocketed.add(coinColor, p.coins().remove(coinColor, count))
+}
+
+// payDue returns a coin player holds to board, black or else white. The red is never
+// returned as due.
+func (p *Player) payDue() bool {
+	for _, coinColor := range []string{black, white} {
+		if p.Pocketed.remove(coinColor, 1) == 1 {
+			p.coins().add(coinColor, 1)
+
+			return true
+		}
 	}
+
+	return false
+}
+
+func (p *Player) totalFouls() int {
+	return len(p.FoulHistory)
 }
 
 // AddPlayersToGame returns true if provided player names are valid.
 // Unique player names and more than one player is considered as valid.
+// Players are added to a new game, abandoning the last game if it is not over.
 func AddPlayersToGame(playerNames []string) bool {
 	if !isValidPlayers(playerNames) {
 		return false
 	}
 
-	// remove players of last game if any.
-	playersOnBoard = []*Player{}
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
 
-	for _, name := range playerNames {
-		playersOnBoard = append(playersOnBoard, newPlayer(name))
-	}
+	defaultGame.Close()
+	defaultGame = NewGame("")
 
-	return true
+	return defaultGame.AddPlayers(playerNames) == nil
 }
 
 func isValidPlayers(playerNames []string) bool {
@@ -52,14 +88,39 @@ func isValidPlayers(playerNames []string) bool {
 	return true
 }
 
-// passPlayer internally rotates player.
-func passPlayer() (p *Player) {
-	if playerIDForTurn == len((playersOnBoard)) {
-		playerIDForTurn = 0
+// currentPlayer returns player who has to play the next turn.
+func (g *Game) currentPlayer() *Player {
+	if g.playerIDForTurn >= len(g.players) {
+		g.playerIDForTurn = 0
 	}
 
-	p = playersOnBoard[playerIDForTurn]
-	playerIDForTurn++
+	return g.players[g.playerIDForTurn]
+}
+
+// passPlayer internally rotates player, skipping eliminated players.
+func (g *Game) passPlayer() {
+	for {
+		g.playerIDForTurn++
+
+		if g.playerIDForTurn == len(g.players) {
+			g.playerIDForTurn = 0
+		}
+
+		if !g.players[g.playerIDForTurn].eliminated {
+			return
+		}
+	}
+}
+
+// otherPlayers returns players on board except p.
+func (g *Game) otherPlayers(p *Player) []*Player {
+	others := make([]*Player, 0, len(g.players))
+
+	for _, other := range g.players {
+		if other != p {
+			others = append(others, other)
+		}
+	}
 
-	return p
+	return others
 }
@@ -0,0 +1,202 @@
+package carrom
+
+import (
+	"fmt"
+	"math"
+)
+
+// Side is a side of the board, the one a player sits at and strikes from.
+type Side int
+
+// Sides of the board, south being the side of the player who breaks.
+const (
+	SideSouth Side = iota + 1
+	SideEast
+	SideNorth
+	SideWest
+)
+
+var sideNames = map[Side]string{
+	SideSouth: "south",
+	SideEast:  "east",
+	SideNorth: "north",
+	SideWest:  "west",
+}
+
+func (s Side) String() string {
+	if name, ok := sideNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Side(%d)", int(s))
+}
+
+// MarshalText encodes side by its name.
+func (s Side) MarshalText() ([]byte, error) {
+	if _, ok := sideNames[s]; !ok {
+		return nil, fmt.Errorf("invalid side %d", int(s))
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes side from its name.
+func (s *Side) UnmarshalText(text []byte) error {
+	for side, name := range sideNames {
+		if name == string(text) {
+			*s = side
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid side %q", text)
+}
+
+// seats are sides players of a game sit at in their order of turns, by count of players.
+// Players of games of more than four players have no seat.
+var seats = map[int][]Side{
+	2: {SideSouth, SideNorth},
+	3: {SideSouth, SideEast, SideNorth},
+	4: {SideSouth, SideEast, SideNorth, SideWest},
+}
+
+// seat sits players at sides of the board in their order of turns.
+func seat(players []*Player) {
+	if seats, ok := seats[len(players)]; ok {
+		for i, p := range players {
+			p.Seat = seats[i]
+		}
+	}
+}
+
+// Pocket is a corner pocket of the board, named by the sides it is between.
+type Pocket int
+
+// Pockets of the board.
+const (
+	PocketSouthWest Pocket = iota + 1
+	PocketSouthEast
+	PocketNorthEast
+	PocketNorthWest
+)
+
+func (p Pocket) String() string {
+	switch p {
+	case PocketSouthWest:
+		return "south-west"
+	case PocketSouthEast:
+		return "south-east"
+	case PocketNorthEast:
+		return "north-east"
+	case PocketNorthWest:
+		return "north-west"
+	}
+
+	return fmt.Sprintf("Pocket(%d)", int(p))
+}
+
+// MarshalText encodes pocket by its name.
+func (p Pocket) MarshalText() ([]byte, error) {
+	if p < PocketSouthWest || p > PocketNorthWest {
+		return nil, fmt.Errorf("invalid pocket %d", int(p))
+	}
+
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText decodes pocket from its name.
+func (p *Pocket) UnmarshalText(text []byte) error {
+	for pocket := PocketSouthWest; pocket <= PocketNorthWest; pocket++ {
+		if pocket.String() == string(text) {
+			*p = pocket
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid pocket %q", text)
+}
+
+// CoinPocket is the pocket a coin of the colour, "black", "white" or "red", went into.
+type CoinPocket struct {
+	Coin   string
+	Pocket Pocket
+}
+
+// validatePlacement returns an error if pockets of the shot don't account for every coin
+// pocketed, or the striker position is off the baseline.
+func (s Shot) validatePlacement() error {
+	if len(s.Pockets) > 0 {
+		counts := make(map[string]int, 3)
+
+		for _, cp := range s.Pockets {
+			if cp.Pocket < PocketSouthWest || cp.Pocket > PocketNorthWest {
+				return fmt.Errorf("invalid shot: invalid pocket %d of %s coin", int(cp.Pocket), cp.Coin)
+			}
+
+			if cp.Coin != black && cp.Coin != white && cp.Coin != red {
+				return fmt.Errorf("invalid shot: invalid coin %q", cp.Coin)
+			}
+
+			counts[cp.Coin]++
+		}
+
+		expected := map[string]int{black: s.Pocketed.Black, white: s.Pocketed.White}
+		if s.Pocketed.IsRedPocketed {
+			expected[red] = 1
+		}
+
+		for _, coin := range []string{black, white, red} {
+			if counts[coin] != expected[coin] {
+				return fmt.Errorf("invalid shot: pockets of %d %s coins given for %d pocketed", counts[coin], coin, expected[coin])
+			}
+		}
+	}
+
+	if s.StrikerSide != 0 {
+		if _, ok := sideNames[s.StrikerSide]; !ok {
+			return fmt.Errorf("invalid shot: invalid striker side %d", int(s.StrikerSide))
+		}
+	}
+
+	// NaN would pass the range check, and can't be saved as JSON.
+	if math.IsNaN(s.StrikerPosition) || math.IsInf(s.StrikerPosition, 0) || s.StrikerPosition < -1 || s.StrikerPosition > 1 {
+		return fmt.Errorf("invalid shot: striker position %g is off the baseline", s.StrikerPosition)
+	}
+
+	return nil
+}
+
+// checkSeat returns an error if the shot was played from a side other than the seat of p.
+func checkSeat(p *Player, s *Shot) error {
+	if s == nil || s.StrikerSide == 0 || p.Seat == 0 || s.StrikerSide == p.Seat {
+		return nil
+	}
+
+	return fmt.Errorf("%s sits at %v, can't strike from %v", p.PlayerName, p.Seat, s.StrikerSide)
+}
+
+// PocketUsage counts coins every player pocketed into every pocket in turns, eg. turns of a
+// game or turns read from the event logs of many games. Only shots carrying pockets are counted.
+func PocketUsage(turns []TurnResult) map[string]map[Pocket]int {
+	usage := make(map[string]map[Pocket]int, 0)
+
+	for _, turn := range turns {
+		if turn.Shot == nil || len(turn.Shot.Pockets) == 0 {
+			continue
+		}
+
+		pockets, ok := usage[turn.PlayerName]
+		if !ok {
+			pockets = make(map[Pocket]int, 4)
+			usage[turn.PlayerName] = pockets
+		}
+
+		for _, cp := range turn.Shot.Pockets {
+			pockets[cp.Pocket]++
+		}
+	}
+
+	return usage
+}
@@ -0,0 +1,119 @@
+package carrom_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestShotPlacement(t *testing.T) {
+	black := carrom.CoinsPocketedCount{Black: 1}
+	sw := carrom.CoinPocket{Coin: "black", Pocket: carrom.PocketSouthWest}
+
+	testCases := []struct {
+		shot        carrom.Shot
+		expectedErr bool
+	}{
+		{carrom.Shot{Pocketed: black, Pockets: []carrom.CoinPocket{sw}, StrikerSide: carrom.SideSouth, StrikerPosition: -0.5}, false},
+		{carrom.Shot{Pocketed: black}, false},
+		{carrom.Shot{Pocketed: carrom.CoinsPocketedCount{White: 1, IsRedPocketed: true},
+			Pockets: []carrom.CoinPocket{{"red", carrom.PocketNorthEast}, {"white", carrom.PocketNorthEast}}}, false},
+		{carrom.Shot{Pocketed: carrom.CoinsPocketedCount{Black: 2}, Pockets: []carrom.CoinPocket{sw}}, true},
+		{carrom.Shot{Pocketed: black, Pockets: []carrom.CoinPocket{{"white", carrom.PocketSouthWest}}}, true},
+		{carrom.Shot{Pocketed: black, Pockets: []carrom.CoinPocket{{"black", carrom.Pocket(5)}}}, true},
+		{carrom.Shot{Pocketed: black, Pockets: []carrom.CoinPocket{sw, {"queen", carrom.PocketSouthWest}}}, true},
+		{carrom.Shot{Pocketed: black, StrikerPosition: 1.5}, true},
+		{carrom.Shot{Pocketed: black, StrikerPosition: math.NaN()}, true},
+		{carrom.Shot{Pocketed: black, StrikerPosition: math.Inf(-1)}, true},
+		{carrom.Shot{Pocketed: black, StrikerSide: carrom.Side(7)}, true},
+		{carrom.Shot{Pocketed: black, StrikerSide: carrom.SideNorth}, true},
+	}
+
+	for _, tc := range testCases {
+		g, _ := startGame(t, "p1", "p2")
+
+		if _, err := g.PlayShot(tc.shot); (err != nil) != tc.expectedErr {
+			t.Errorf("PlayShot(%+v)= %v, want error: %t", tc.shot, err, tc.expectedErr)
+		}
+
+		g.Close()
+	}
+}
+
+func TestSeats(t *testing.T) {
+	testCases := []struct {
+		players       []string
+		expectedSeats []carrom.Side
+	}{
+		{[]string{"p1", "p2"}, []carrom.Side{carrom.SideSouth, carrom.SideNorth}},
+		{[]string{"p1", "p2", "p3"}, []carrom.Side{carrom.SideSouth, carrom.SideEast, carrom.SideNorth}},
+		{[]string{"p1", "p2", "p3", "p4"}, []carrom.Side{carrom.SideSouth, carrom.SideEast, carrom.SideNorth, carrom.SideWest}},
+		{[]string{"p1", "p2", "p3", "p4", "p5"}, []carrom.Side{0, 0, 0, 0, 0}},
+	}
+
+	for _, tc := range testCases {
+		g := carrom.NewGame("")
+		_ = g.AddPlayers(tc.players)
+
+		actual := make([]carrom.Side, 0, len(tc.players))
+		for _, p := range g.Players() {
+			actual = append(actual, p.Seat)
+		}
+
+		if !reflect.DeepEqual(actual, tc.expectedSeats) {
+			t.Errorf("seats of %v= %v, want= %v", tc.players, actual, tc.expectedSeats)
+		}
+	}
+}
+
+func TestPocketUsage(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	shots := []carrom.Shot{
+		{Pocketed: carrom.CoinsPocketedCount{Black: 2}, StrikerSide: carrom.SideSouth,
+			Pockets: []carrom.CoinPocket{{"black", carrom.PocketNorthWest}, {"black", carrom.PocketNorthEast}}},
+		{Pocketed: carrom.CoinsPocketedCount{White: 1}, StrikerSide: carrom.SideNorth,
+			Pockets: []carrom.CoinPocket{{"white", carrom.PocketSouthEast}}},
+		{Pocketed: carrom.CoinsPocketedCount{Black: 1}, Pockets: []carrom.CoinPocket{{"black", carrom.PocketNorthWest}}},
+		{Pocketed: carrom.CoinsPocketedCount{White: 1}},
+	}
+
+	for _, s := range shots {
+		if _, err := g.PlayShot(s); err != nil {
+			t.Fatalf("PlayShot(%+v)= %v, want= nil", s, err)
+		}
+	}
+
+	playTurnsOn(t, g, carrom.Input{StrikeCode: 5})
+
+	expected := map[string]map[carrom.Pocket]int{
+		"p1": {carrom.PocketNorthWest: 2, carrom.PocketNorthEast: 1},
+		"p2": {carrom.PocketSouthEast: 1},
+	}
+
+	if actual := carrom.PocketUsage(g.Turns()); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("PocketUsage()= %v, want= %v", actual, expected)
+	}
+}
+
+func TestShotJSON(t *testing.T) {
+	res := carrom.TurnResult{Shot: &carrom.Shot{
+		Pocketed:    carrom.CoinsPocketedCount{Black: 1},
+		Pockets:     []carrom.CoinPocket{{"black", carrom.PocketSouthEast}},
+		StrikerSide: carrom.SideWest,
+	}}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("Marshal()= %v, want= nil", err)
+	}
+
+	var actual carrom.TurnResult
+	if err := json.Unmarshal(data, &actual); err != nil || !reflect.DeepEqual(actual, res) {
+		t.Errorf("Unmarshal(%s)= %+v, err: %v, want= %+v", data, actual, err, res)
+	}
+}
@@ -0,0 +1,183 @@
+package carrom
+
+import "fmt"
+
+// Position is a game mid-way, for drills, puzzles and reproducing bugs, see Game.SetPosition.
+type Position struct {
+	// Coins is coins left on board.
+	Coins   Coins
+	Players []PlayerPosition
+	// Turn is count of turns played before the position, counting to the turn cap.
+	Turn int
+	// CurrentPlayer plays the next turn. The first player plays it if it is empty.
+	CurrentPlayer string
+}
+
+// PlayerPosition is a player in a position. FoulCount is fouls counting to the next point lost,
+// NoPocketCount is the streak of turns without pocketing a coin and Pocketed is coins he holds.
+type PlayerPosition struct {
+	PlayerName    string
+	Points        int
+	FoulCount     int
+	NoPocketCount int
+	Pocketed      Coins
+}
+
+// validate returns an error if the position can't be reached in a game.
+func (pos Position) validate(handicaps map[string]Handicap) error {
+	names := make([]string, 0, len(pos.Players))
+	held := pos.Coins
+
+	for _, p := range pos.Players {
+		names = append(names, p.PlayerName)
+
+		if p.FoulCount < 0 || p.FoulCount >= foulsAllowed+handicaps[p.PlayerName].ExtraFouls {
+			return fmt.Errorf("invalid position: %s has %d fouls counting to a point", p.PlayerName, p.FoulCount)
+		}
+
+		if p.NoPocketCount < 0 || p.NoPocketCount >= 3 {
+			return fmt.Errorf("invalid position: %s missed %d turns in a row", p.PlayerName, p.NoPocketCount)
+		}
+
+		if p.Pocketed.Red < 0 || p.Pocketed.Black < 0 || p.Pocketed.White < 0 {
+			return fmt.Errorf("invalid position: %s holds %+v", p.PlayerName, p.Pocketed)
+		}
+
+		held.Red += p.Pocketed.Red
+		held.Black += p.Pocketed.Black
+		held.White += p.Pocketed.White
+	}
+
+	if !isValidPlayers(names) {
+		return fmt.Errorf("invalid position: invalid player names %v", names)
+	}
+
+	if pos.Coins.Red < 0 || pos.Coins.Black < 0 || pos.Coins.White < 0 ||
+		held.Red > 1 || held.Black > 9 || held.White > 9 {
+		return fmt.Errorf("invalid position: %+v on board and %+v with players and on board are more than a set", pos.Coins, held)
+	}
+
+	if pos.Turn < 0 {
+		return fmt.Errorf("invalid position: invalid turn %d", pos.Turn)
+	}
+
+	if pos.CurrentPlayer != "" && !contains(names, pos.CurrentPlayer) {
+		return fmt.Errorf("invalid position: current player %q is not in game", pos.CurrentPlayer)
+	}
+
+	return nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetPosition adds players of the position to the game, replacing players added before, and
+// sets the game to start at the position. An error is returned if the position can't be
+// reached in a game, or if the game is over at it.
+func (g *Game) SetPosition(pos Position) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("set position", Setup); err != nil {
+		return err
+	}
+
+	if err := pos.validate(g.handicaps); err != nil {
+		return err
+	}
+
+	coins := pos.Coins
+	players := make([]*Player, 0, len(pos.Players))
+	current := 0
+
+	for i, pp := range pos.Players {
+		p := newPlayer(pp.PlayerName, g.handicaps[pp.PlayerName])
+		p.Points = pp.Points
+		p.FoulCount = pp.FoulCount
+		p.NoPocketCount = pp.NoPocketCount
+		p.Pocketed = pp.Pocketed
+		p.redPocketed = pp.Pocketed.Red
+
+		if pp.PlayerName == pos.CurrentPlayer {
+			current = i
+		}
+
+		players = append(players, p)
+	}
+
+	seat(players)
+
+	saved := g.players
+	g.players, g.coins = players, &coins
+
+	over := g.isBoardEmpty() || g.getWinner() != nil
+	g.coins = nil
+
+	if over {
+		g.players = saved
+
+		return fmt.Errorf("invalid position: game is over at it")
+	}
+
+	g.startCoins = &coins
+	g.playerIDForTurn = current
+	g.turnCount = pos.Turn
+	g.rounds = pos.Turn / len(players)
+
+	return nil
+}
+
+// SetPosition sets up a new game at the position, abandoning the last game if it is not over.
+// The game is started by NewBoard.
+func SetPosition(pos Position) error {
+	g := NewGame("")
+	if err := g.SetPosition(pos); err != nil {
+		return err
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	defaultGame.Close()
+	defaultGame = g
+
+	return nil
+}
+
+// Position returns position of the game, to set up a game continuing from it. False is returned
+// if the game is not in progress, or has eliminated players, a re-racked board or reached its turn
+// cap, which a position can't hold.
+func (g *Game) Position() (Position, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state != InProgress || g.deciding || g.capTurn != 0 || len(g.eliminated) > 0 {
+		return Position{}, false
+	}
+
+	pos := Position{
+		Coins:         *g.coins,
+		Players:       make([]PlayerPosition, 0, len(g.players)),
+		Turn:          g.turnCount,
+		CurrentPlayer: g.currentPlayer().PlayerName,
+	}
+
+	for _, p := range g.players {
+		pos.Players = append(pos.Players, PlayerPosition{
+			PlayerName:    p.PlayerName,
+			Points:        p.Points,
+			FoulCount:     p.FoulCount,
+			NoPocketCount: p.NoPocketCount,
+			Pocketed:      p.Pocketed,
+		})
+	}
+
+	return pos, true
+}
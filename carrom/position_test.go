@@ -0,0 +1,126 @@
+package carrom_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// endgame is a position where p2 plays with one black coin left and p1 leading by 1.
+var endgame = carrom.Position{
+	Coins: carrom.Coins{Black: 1},
+	Players: []carrom.PlayerPosition{
+		{PlayerName: "p1", Points: 5, FoulCount: 2, Pocketed: carrom.Coins{Red: 1, Black: 4, White: 5}},
+		{PlayerName: "p2", Points: 4, NoPocketCount: 2, Pocketed: carrom.Coins{Black: 3, White: 4}},
+	},
+	Turn:          20,
+	CurrentPlayer: "p2",
+}
+
+func TestSetPosition(t *testing.T) {
+	g := carrom.NewGame("")
+	if err := g.SetPosition(endgame); err != nil {
+		t.Fatalf("SetPosition()= %v, want= nil", err)
+	}
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	s := g.Snapshot()
+	if s.Turn != 20 || s.CurrentPlayer != "p2" || s.Coins != endgame.Coins || s.Players[0].FoulCount != 2 || s.Players[1].NoPocketCount != 2 {
+		t.Errorf("Snapshot() at position= %+v, want= %+v", s, endgame)
+	}
+
+	// a third miss costs p2 a point, then p1 fouls for the third time and loses two.
+	playTurnsOn(t, g, carrom.Input{StrikeCode: 5}, carrom.Input{StrikeCode: 3})
+
+	if s := g.Snapshot(); s.Players[0].Points != 3 || s.Players[1].Points != 3 || s.Turn != 22 {
+		t.Errorf("Snapshot() after 2 turns= %+v, want= p1 3, p2 3", s)
+	}
+
+	playTurnsOn(t, g, carrom.Input{StrikeCode: 0, CoinsPocketedCount: carrom.CoinsPocketedCount{Black: 1}})
+
+	if o := g.Outcome(); o.Kind != carrom.OutcomeDrawn || o.Reason != carrom.ReasonBoardExhausted {
+		t.Errorf("Outcome()= %+v, want= drawn as board is exhausted", o)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	g := carrom.NewGame("")
+	if err := g.SetPosition(endgame); err != nil {
+		t.Fatalf("SetPosition()= %v, want= nil", err)
+	}
+
+	if _, ok := g.Position(); ok {
+		t.Errorf("Position() of game in setup= true, want= false")
+	}
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	if pos, ok := g.Position(); !ok || !reflect.DeepEqual(pos, endgame) {
+		t.Errorf("Position()= %+v, %t, want= %+v", pos, ok, endgame)
+	}
+}
+
+func TestSetPositionInvalid(t *testing.T) {
+	modify := func(f func(pos *carrom.Position)) carrom.Position {
+		pos := endgame
+		pos.Players = append([]carrom.PlayerPosition(nil), endgame.Players...)
+		f(&pos)
+
+		return pos
+	}
+
+	testCases := []carrom.Position{
+		modify(func(pos *carrom.Position) { pos.Players = pos.Players[:1] }),
+		modify(func(pos *carrom.Position) { pos.Players[1].PlayerName = "p1" }),
+		modify(func(pos *carrom.Position) { pos.Coins.Red = 1 }),
+		modify(func(pos *carrom.Position) { pos.Coins.White = -1 }),
+		modify(func(pos *carrom.Position) { pos.Players[1].Pocketed.Black = 5 }),
+		modify(func(pos *carrom.Position) { pos.Players[0].FoulCount = 3 }),
+		modify(func(pos *carrom.Position) { pos.Players[1].NoPocketCount = 3 }),
+		modify(func(pos *carrom.Position) { pos.Turn = -1 }),
+		modify(func(pos *carrom.Position) { pos.CurrentPlayer = "p3" }),
+		modify(func(pos *carrom.Position) { pos.Players[0].Points = 7 }),
+		modify(func(pos *carrom.Position) { pos.Coins = carrom.Coins{} }),
+	}
+
+	for _, pos := range testCases {
+		g := carrom.NewGame("")
+
+		if err := g.SetPosition(pos); err == nil {
+			t.Errorf("SetPosition(%+v)= nil, want= error", pos)
+		}
+	}
+
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	if err := g.SetPosition(endgame); err == nil {
+		t.Errorf("SetPosition() of game in progress= nil, want= error")
+	}
+}
+
+func TestSetPositionThenAddPlayers(t *testing.T) {
+	g := carrom.NewGame("")
+	_ = g.SetPosition(endgame)
+	_ = g.AddPlayers([]string{"p1", "p2"})
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	if s := g.Snapshot(); s.Turn != 0 || s.CurrentPlayer != "p1" || s.Coins != (carrom.Coins{Red: 1, White: 9, Black: 9}) {
+		t.Errorf("Snapshot()= %+v, want= a new game", s)
+	}
+}
@@ -0,0 +1,243 @@
+package carrom
+
+import (
+	"math/rand"
+	"testing"
+
+	l "github.com/sirupsen/logrus"
+)
+
+// model is an independent bookkeeping of the README rules. The engine is expected
+// to agree with it after every turn.
+type model struct {
+	coins    Coins
+	points   []int
+	fouls    []int
+	misses   []int
+	nextTurn int
+}
+
+func newModel(players int) *model {
+	return &model{
+		coins:  Coins{Red: 1, White: 9, Black: 9},
+		points: make([]int, players),
+		fouls:  make([]int, players),
+		misses: make([]int, players),
+	}
+}
+
+// play returns false if the rules don't allow the input.
+func (m *model) play(c Input) bool {
+	i := m.nextTurn
+	pocketed := c.CoinsPocketedCount
+	withinBoard := pocketed.Black <= m.coins.Black && pocketed.White <= m.coins.White &&
+		(!pocketed.IsRedPocketed || m.coins.Red > 0)
+
+	coins := pocketed.Black + pocketed.White
+
+	// strike codes must agree with the coins pocketed.
+	switch {
+	case pocketed.Black < 0 || pocketed.White < 0,
+		c.StrikeCode == 0 && (coins != 1 || pocketed.IsRedPocketed),
+		c.StrikeCode == 1 && coins == 1 && !pocketed.IsRedPocketed,
+		c.StrikeCode == 2 && coins > 0,
+		(c.StrikeCode == 3 || c.StrikeCode == 5) && (coins > 0 || pocketed.IsRedPocketed):
+		return false
+	}
+
+	switch c.StrikeCode {
+	case 0:
+		if !withinBoard || (pocketed.Black < 1 && pocketed.White < 1) {
+			return false
+		}
+
+		m.points[i]++
+		m.take(Coins{Black: pocketed.Black, White: pocketed.White})
+	case 1:
+		if !withinBoard || (pocketed.Black == 0 && pocketed.White == 0) {
+			return false
+		}
+
+		m.points[i] += 2
+		m.take(Coins{Black: pocketed.Black, White: pocketed.White, Red: boolToInt(pocketed.IsRedPocketed)})
+	case 2:
+		if m.coins.Red == 0 {
+			return false
+		}
+
+		m.points[i] += 3
+		m.take(Coins{Red: 1})
+	case 3:
+		m.points[i]--
+		m.foul(i)
+	case 4:
+		if !withinBoard || (pocketed.Black == 0 && pocketed.White == 0 && !pocketed.IsRedPocketed) {
+			return false
+		}
+
+		m.points[i] -= 2
+		m.foul(i)
+		m.take(Coins{Black: pocketed.Black, White: pocketed.White, Red: boolToInt(pocketed.IsRedPocketed)})
+	case 5:
+		m.misses[i]++
+
+		if m.misses[i] == 3 {
+			m.misses[i] = 0
+			m.points[i]--
+			m.foul(i)
+		}
+	default:
+		return false
+	}
+
+	m.nextTurn = (i + 1) % len(m.points)
+
+	return true
+}
+
+func (m *model) foul(i int) {
+	m.fouls[i]++
+
+	if m.fouls[i] == 3 {
+		m.fouls[i] = 0
+		m.points[i]--
+	}
+}
+
+func (m *model) take(c Coins) {
+	m.coins.Black = maxInt(m.coins.Black-c.Black, 0)
+	m.coins.White = maxInt(m.coins.White-c.White, 0)
+	m.coins.Red = maxInt(m.coins.Red-c.Red, 0)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// randomInput mostly generates inputs the board can satisfy but also
+// out of range codes and coin counts.
+func randomInput(r *rand.Rand) Input {
+	count := func() int {
+		if r.Intn(10) == 0 {
+			return r.Intn(12) - 1
+		}
+
+		return r.Intn(3)
+	}
+
+	return Input{
+		StrikeCode: r.Intn(8) - 1,
+		CoinsPocketedCount: CoinsPocketedCount{
+			Black:         count(),
+			White:         count(),
+			IsRedPocketed: r.Intn(4) == 0,
+		},
+	}
+}
+
+// checkTurn plays c on the engine and the model and reports every invariant broken.
+func checkTurn(t *testing.T, m *model, deltas map[string]int, c Input) {
+	t.Helper()
+
+	expectedPlayer := defaultGame.players[m.nextTurn].PlayerName
+	valid := m.play(c)
+
+	res, err := PlayTurn(c)
+	if valid != (err == nil) {
+		t.Fatalf("PlayTurn(%v)= err: %v, want valid= %t", c, err, valid)
+	}
+
+	if err == nil {
+		if res.PlayerName != expectedPlayer {
+			t.Fatalf("PlayTurn(%v)= player: %s, want= player: %s", c, res.PlayerName, expectedPlayer)
+		}
+
+		deltas[res.PlayerName] += res.PointsDelta
+	}
+
+	if CoinsOnBoard.Black < 0 || CoinsOnBoard.White < 0 || CoinsOnBoard.Red < 0 {
+		t.Fatalf("PlayTurn(%v) left negative coins on board: %+v", c, *CoinsOnBoard)
+	}
+
+	if *CoinsOnBoard != m.coins {
+		t.Fatalf("PlayTurn(%v)= coins: %+v, want= coins: %+v", c, *CoinsOnBoard, m.coins)
+	}
+
+	held := *CoinsOnBoard
+
+	for _, p := range defaultGame.players {
+		held.add(black, p.Pocketed.Black)
+		held.add(white, p.Pocketed.White)
+		held.add(red, p.Pocketed.Red)
+	}
+
+	if held.Black > 9 || held.White > 9 || held.Red > 1 {
+		t.Fatalf("PlayTurn(%v)= coins held and on board: %+v, want= at most a set", c, held)
+	}
+
+	for i, p := range defaultGame.players {
+		if p.Points != m.points[i] || p.Points != deltas[p.PlayerName] {
+			t.Fatalf("PlayTurn(%v)= %s score: %d, sum of turn deltas: %d, want= score: %d",
+				c, p.PlayerName, p.Points, deltas[p.PlayerName], m.points[i])
+		}
+	}
+}
+
+func TestRandomGamesKeepInvariants(t *testing.T) {
+	l.SetLevel(l.FatalLevel)
+	defer l.SetLevel(l.InfoLevel)
+
+	names := []string{"p1", "p2", "p3", "p4"}
+
+	for seed := int64(0); seed < 200; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		players := names[:2+r.Intn(3)]
+
+		AddPlayersToGame(players)
+		NewBoard()
+
+		m := newModel(len(players))
+		deltas := make(map[string]int, len(players))
+
+		for turn := 0; turn < 500 && defaultGame.getWinner() == nil; turn++ {
+			checkTurn(t, m, deltas, randomInput(r))
+
+			if defaultGame.isBoardEmpty() && !IsGameOver() {
+				t.Fatalf("seed %d: board is empty but game is not over", seed)
+			}
+
+			if defaultGame.isBoardEmpty() {
+				break
+			}
+		}
+	}
+}
+
+func TestShotModelSamplesArePlayable(t *testing.T) {
+	l.SetLevel(l.FatalLevel)
+	defer l.SetLevel(l.InfoLevel)
+
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		coins := Coins{Red: r.Intn(2), Black: r.Intn(3), White: r.Intn(3)}
+		c := averageShotModel.Sample(r, coins)
+
+		p := &Player{board: &coins}
+		if err := c.validate(); err != nil || p.play(c) != nil {
+			t.Fatalf("Sample() on %+v= %v, err: %v, want= a playable input", coins, c, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package carrom
+
+import (
+	"fmt"
+	"sort"
+)
+
+// VictoryCondition decides who the leader has to be ahead of to win a game of more than two players.
+// Both conditions are the same for two players.
+type VictoryCondition int
+
+const (
+	// LeadOverRunnerUp wins the game for the leader once he leads the runner-up, and so every
+	// other player, by the lead needed.
+	LeadOverRunnerUp VictoryCondition = iota
+	// LeadOverAll wins the game for the leader once he leads points of all other players
+	// added up by the lead needed.
+	LeadOverAll
+)
+
+// TieBreaker separates players with the same points when they are placed.
+type TieBreaker int
+
+const (
+	// FewerFouls places the player with fewer fouls in the game first.
+	FewerFouls TieBreaker = iota + 1
+	// MoreRedPocketed places the player who pocketed the red coin first.
+	MoreRedPocketed
+)
+
+// Ranking decides the winner and places of players in a game. Players tied on points are
+// separated by TieBreakers in the order given, and share the place if they are still tied.
+type Ranking struct {
+	Victory     VictoryCondition
+	TieBreakers []TieBreaker
+}
+
+func (r Ranking) validate() error {
+	if r.Victory != LeadOverRunnerUp && r.Victory != LeadOverAll {
+		return fmt.Errorf("invalid victory condition %d", r.Victory)
+	}
+
+	for _, tb := range r.TieBreakers {
+		if tb != FewerFouls && tb != MoreRedPocketed {
+			return fmt.Errorf("invalid tie breaker %d", tb)
+		}
+	}
+
+	return nil
+}
+
+func (r Ranking) copy() Ranking {
+	r.TieBreakers = append([]TieBreaker(nil), r.TieBreakers...)
+
+	return r
+}
+
+// ranking is the ranking new games start with.
+var ranking Ranking
+
+// SetRanking sets ranking of the games created after it by NewBoard or NewGame.
+// Pass Ranking{} to rank by points alone.
+func SetRanking(r Ranking) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	ranking = r.copy()
+
+	return nil
+}
+
+// SetRanking sets ranking of a game being set up.
+func (g *Game) SetRanking(r Ranking) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("set ranking", Setup); err != nil {
+		return err
+	}
+
+	g.ranking = r.copy()
+
+	return nil
+}
+
+// compare returns a negative number if a is placed before b, a positive number if b is
+// placed before a and 0 if they are tied.
+func (r Ranking) compare(a, b *Player) int {
+	if a.Points != b.Points {
+		return b.Points - a.Points
+	}
+
+	for _, tb := range r.TieBreakers {
+		switch {
+		case tb == FewerFouls && a.totalFouls() != b.totalFouls():
+			return a.totalFouls() - b.totalFouls()
+		case tb == MoreRedPocketed && a.redPocketed != b.redPocketed:
+			return b.redPocketed - a.redPocketed
+		}
+	}
+
+	return 0
+}
+
+// ranked returns players placed by ranking of the game, keeping the order of play on ties.
+// Eliminated players and excluded player, if any, are left out.
+func (g *Game) ranked(excluded *Player) []*Player {
+	players := make([]*Player, 0, len(g.players))
+	for _, p := range g.players {
+		if p != excluded && !p.eliminated {
+			players = append(players, p)
+		}
+	}
+
+	sort.SliceStable(players, func(i, j int) bool {
+		return g.ranking.compare(players[i], players[j]) < 0
+	})
+
+	return players
+}
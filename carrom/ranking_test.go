@@ -0,0 +1,112 @@
+package carrom_test
+
+import (
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func b(black int) carrom.CoinsPocketedCount {
+	return carrom.CoinsPocketedCount{Black: black}
+}
+
+func TestVictoryCondition(t *testing.T) {
+	// p1 leads the runner-up by 3 but the other two players together by 1.
+	leadOverRunnerUp := []carrom.Input{{StrikeCode: 2}, {1, b(2)}, {1, b(2)}, {1, b(2)}}
+	// p1 leads p3 by 5 but p2 only by 1.
+	leadOverLast := []carrom.Input{{1, b(2)}, {StrikeCode: 2}, {StrikeCode: 5}, {1, b(2)}, {0, b(1)}, {StrikeCode: 5}, {0, b(1)}}
+
+	testCases := []struct {
+		victory        carrom.VictoryCondition
+		inputs         []carrom.Input
+		expectedWinner string
+		expectedMargin int
+	}{
+		{carrom.LeadOverRunnerUp, leadOverRunnerUp, "p1", 3},
+		{carrom.LeadOverAll, leadOverRunnerUp, "", 0},
+		{carrom.LeadOverRunnerUp, leadOverLast, "", 0},
+		{carrom.LeadOverAll, leadOverLast, "", 0},
+	}
+
+	for _, tc := range testCases {
+		g := carrom.NewGame("")
+		if err := g.SetRanking(carrom.Ranking{Victory: tc.victory}); err != nil {
+			t.Fatalf("SetRanking()= %v, want= nil", err)
+		}
+
+		if err := g.AddPlayers([]string{"p1", "p2", "p3"}); err != nil {
+			t.Fatalf("AddPlayers()= %v, want= nil", err)
+		}
+
+		if _, err := g.Start(); err != nil {
+			t.Fatalf("Start()= %v, want= nil", err)
+		}
+
+		for _, c := range tc.inputs {
+			if _, err := g.PlayTurn(c); err != nil {
+				t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+			}
+		}
+
+		if o := g.Outcome(); o.Winner != tc.expectedWinner || o.Margin != tc.expectedMargin {
+			t.Errorf("Outcome() with victory %d after %v= winner: %q, margin: %d, want= winner: %q, margin: %d",
+				tc.victory, tc.inputs, o.Winner, o.Margin, tc.expectedWinner, tc.expectedMargin)
+		}
+
+		g.Close()
+	}
+}
+
+func TestPlacement(t *testing.T) {
+	// p2 and p3 end with 2 points each. p2 pocketed the red and fouled once, p3 did neither.
+	inputs := []carrom.Input{{StrikeCode: 5}, {StrikeCode: 2}, {1, b(2)}, {StrikeCode: 5}, {StrikeCode: 3}, {StrikeCode: 5}}
+
+	testCases := []struct {
+		tieBreakers    []carrom.TieBreaker
+		expectedPlaces map[string]int
+		expectedOrder  []string
+	}{
+		{nil, map[string]int{"p1": 3, "p2": 1, "p3": 1}, []string{"p2", "p3", "p1"}},
+		{[]carrom.TieBreaker{carrom.FewerFouls}, map[string]int{"p1": 3, "p2": 2, "p3": 1}, []string{"p3", "p2", "p1"}},
+		{[]carrom.TieBreaker{carrom.MoreRedPocketed}, map[string]int{"p1": 3, "p2": 1, "p3": 2}, []string{"p2", "p3", "p1"}},
+		{[]carrom.TieBreaker{carrom.MoreRedPocketed, carrom.FewerFouls}, map[string]int{"p1": 3, "p2": 1, "p3": 2}, []string{"p2", "p3", "p1"}},
+	}
+
+	for _, tc := range testCases {
+		g := carrom.NewGame("")
+		if err := g.SetRanking(carrom.Ranking{TieBreakers: tc.tieBreakers}); err != nil {
+			t.Fatalf("SetRanking()= %v, want= nil", err)
+		}
+
+		if err := g.AddPlayers([]string{"p1", "p2", "p3"}); err != nil {
+			t.Fatalf("AddPlayers()= %v, want= nil", err)
+		}
+
+		if _, err := g.Start(); err != nil {
+			t.Fatalf("Start()= %v, want= nil", err)
+		}
+
+		for _, c := range inputs {
+			if _, err := g.PlayTurn(c); err != nil {
+				t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+			}
+		}
+
+		for i, s := range g.Outcome().Standings {
+			if s.PlayerName != tc.expectedOrder[i] || s.Place != tc.expectedPlaces[s.PlayerName] {
+				t.Errorf("Standings[%d] with tie breakers %v= %s at %d, want= %s at %d",
+					i, tc.tieBreakers, s.PlayerName, s.Place, tc.expectedOrder[i], tc.expectedPlaces[tc.expectedOrder[i]])
+			}
+		}
+
+		g.Close()
+	}
+}
+
+func TestSetRankingInvalid(t *testing.T) {
+	for _, r := range []carrom.Ranking{{Victory: 2}, {TieBreakers: []carrom.TieBreaker{0}}} {
+		if err := carrom.SetRanking(r); err == nil {
+			t.Errorf("SetRanking(%+v)= nil, want= error", r)
+		}
+	}
+}
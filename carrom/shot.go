@@ -0,0 +1,159 @@
+package carrom
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrContradictoryInput is returned, wrapped with the input, for strike codes which don't
+// agree with the coins pocketed, eg. a strike with five coins or a red strike with black coins.
+var ErrContradictoryInput = errors.New("strike code contradicts coins pocketed")
+
+// Shot is what physically happened in a turn, for callers who leave classifying
+// the strike to the engine.
+type Shot struct {
+	// Pocketed is coins pocketed.
+	Pocketed CoinsPocketedCount
+	// StrikerPocketed is set if the striker went into a pocket.
+	StrikerPocketed bool
+	// Thrown is coins thrown off the board.
+	Thrown CoinsPocketedCount
+
+	// Pockets optionally gives the pocket of every coin pocketed.
+	Pockets []CoinPocket `json:",omitempty"`
+	// StrikerSide optionally gives the side the striker was played from, which must be the seat
+	// of the player. StrikerPosition is where on its baseline, from -1 at the left end to 1 at
+	// the right end as seen by the player.
+	StrikerSide     Side    `json:",omitempty"`
+	StrikerPosition float64 `json:",omitempty"`
+}
+
+// Classify returns input of the strike the shot is by the rules,
+//
+//	coins thrown off the board - defunct
+//	striker pocketed - striker strike
+//	red pocketed - red strike, other coins get back on to the board
+//	more than one coin pocketed - multi strike
+//	a coin pocketed - strike
+//	nothing pocketed - no coin pocketed
+//
+// Shots which are more than one strike, eg. pocketing a coin and the striker, are compound
+// shots and have no single input. An error is returned for them, they are played by PlayShot.
+func (s Shot) Classify() (Input, error) {
+	strikes, err := s.strikes()
+	if err != nil {
+		return Input{}, err
+	}
+
+	if len(strikes) > 1 {
+		return Input{}, fmt.Errorf("shot %+v is a compound shot of %v", s, strikes)
+	}
+
+	return strikes[0], nil
+}
+
+// Shot returns the shot input is, so that Shot.Classify returns the input, eg. for bots which
+// choose strikes but play shots. Multi strikes with the red coin are shots of red strikes.
+// Strike codes out of range are a shot of no coin pocketed.
+func (c Input) Shot() Shot {
+	switch c.StrikeCode {
+	case 0, 1:
+		return Shot{Pocketed: c.CoinsPocketedCount}
+	case 2:
+		return Shot{Pocketed: CoinsPocketedCount{IsRedPocketed: true}}
+	case 3:
+		return Shot{StrikerPocketed: true}
+	case 4:
+		return Shot{Thrown: c.CoinsPocketedCount}
+	}
+
+	return Shot{}
+}
+
+// strikes returns the strikes the shot is by the rules, in their order of precedence:
+// coins thrown off the board, the striker and then the coins pocketed.
+func (s Shot) strikes() ([]Input, error) {
+	if s.Pocketed.Black < 0 || s.Pocketed.White < 0 || s.Thrown.Black < 0 || s.Thrown.White < 0 {
+		return nil, fmt.Errorf("invalid shot %+v: negative coin count", s)
+	}
+
+	if s.Pocketed.IsRedPocketed && s.Thrown.IsRedPocketed {
+		return nil, fmt.Errorf("invalid shot %+v: red coin is both pocketed and thrown off", s)
+	}
+
+	if err := s.validatePlacement(); err != nil {
+		return nil, err
+	}
+
+	var strikes []Input
+
+	if s.Thrown.Black+s.Thrown.White > 0 || s.Thrown.IsRedPocketed {
+		strikes = append(strikes, Input{StrikeCode: 4, CoinsPocketedCount: s.Thrown})
+	}
+
+	if s.StrikerPocketed {
+		strikes = append(strikes, Input{StrikeCode: 3})
+	}
+
+	switch pocketed := s.Pocketed.Black + s.Pocketed.White; {
+	case s.Pocketed.IsRedPocketed:
+		strikes = append(strikes, Input{StrikeCode: 2})
+	case pocketed > 1:
+		strikes = append(strikes, Input{StrikeCode: 1, CoinsPocketedCount: s.Pocketed})
+	case pocketed == 1:
+		strikes = append(strikes, Input{StrikeCode: 0, CoinsPocketedCount: s.Pocketed})
+	case len(strikes) == 0:
+		strikes = append(strikes, Input{StrikeCode: 5})
+	}
+
+	return strikes, nil
+}
+
+// validate returns an error if coins pocketed don't agree with the strike code.
+// Strike codes out of range are left to PlayTurn.
+func (c Input) validate() error {
+	coins := c.Black + c.White
+
+	if c.Black < 0 || c.White < 0 {
+		return fmt.Errorf("input %q: negative coin count: %w", c, ErrContradictoryInput)
+	}
+
+	var contradicts bool
+
+	switch c.StrikeCode {
+	case 0:
+		contradicts = coins != 1 || c.IsRedPocketed
+	case 1:
+		contradicts = coins == 0 || (coins == 1 && !c.IsRedPocketed)
+	case 2:
+		contradicts = coins > 0
+	case 3, 5:
+		contradicts = coins > 0 || c.IsRedPocketed
+	}
+
+	if contradicts {
+		return fmt.Errorf("input %q: %w", c, ErrContradictoryInput)
+	}
+
+	return nil
+}
+
+// PlayShot plays the shot as PlayTurn does. A compound shot is played as all the strikes
+// it is, in their order of precedence, see Shot.Classify. Points of every strike are counted,
+// while the turn is counted as one foul if any of the strikes is a foul.
+// The shot is recorded in the turn result.
+func (g *Game) PlayShot(s Shot) (TurnResult, error) {
+	strikes, err := s.strikes()
+	if err != nil {
+		return TurnResult{}, err
+	}
+
+	s.Pockets = append([]CoinPocket(nil), s.Pockets...)
+
+	return g.play(strikes, &s)
+}
+
+// PlayShot plays the shot on the game started by NewBoard.
+func PlayShot(s Shot) (TurnResult, error) {
+	return getDefaultGame().PlayShot(s)
+}
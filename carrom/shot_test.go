@@ -0,0 +1,136 @@
+package carrom_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestShotClassify(t *testing.T) {
+	testCases := []struct {
+		shot          carrom.Shot
+		expectedInput carrom.Input
+		expectedErr   bool
+	}{
+		{carrom.Shot{}, carrom.Input{StrikeCode: 5}, false},
+		{carrom.Shot{Pocketed: carrom.CoinsPocketedCount{White: 1}}, carrom.Input{0, carrom.CoinsPocketedCount{White: 1}}, false},
+		{carrom.Shot{Pocketed: carrom.CoinsPocketedCount{Black: 3, White: 2}}, carrom.Input{1, carrom.CoinsPocketedCount{Black: 3, White: 2}}, false},
+		{carrom.Shot{Pocketed: carrom.CoinsPocketedCount{Black: 1, IsRedPocketed: true}}, carrom.Input{StrikeCode: 2}, false},
+		{carrom.Shot{StrikerPocketed: true}, carrom.Input{StrikeCode: 3}, false},
+		{carrom.Shot{Thrown: carrom.CoinsPocketedCount{IsRedPocketed: true}}, carrom.Input{4, carrom.CoinsPocketedCount{IsRedPocketed: true}}, false},
+		{carrom.Shot{StrikerPocketed: true, Pocketed: carrom.CoinsPocketedCount{Black: 1}}, carrom.Input{}, true},
+		{carrom.Shot{Thrown: carrom.CoinsPocketedCount{Black: 1}, Pocketed: carrom.CoinsPocketedCount{White: 1}}, carrom.Input{}, true},
+		{carrom.Shot{Pocketed: carrom.CoinsPocketedCount{Black: -1}}, carrom.Input{}, true},
+		{carrom.Shot{Pocketed: carrom.CoinsPocketedCount{IsRedPocketed: true}, Thrown: carrom.CoinsPocketedCount{IsRedPocketed: true}}, carrom.Input{}, true},
+	}
+
+	for _, tc := range testCases {
+		actual, err := tc.shot.Classify()
+		if actual != tc.expectedInput || (err != nil) != tc.expectedErr {
+			t.Errorf("Classify(%+v)= %v, err: %v, want= %v, err: %t", tc.shot, actual, err, tc.expectedInput, tc.expectedErr)
+		}
+	}
+}
+
+func TestInputShot(t *testing.T) {
+	inputs := []carrom.Input{
+		{StrikeCode: 5},
+		{0, carrom.CoinsPocketedCount{Black: 1}},
+		{1, carrom.CoinsPocketedCount{Black: 1, White: 2}},
+		{StrikeCode: 2},
+		{StrikeCode: 3},
+		{4, carrom.CoinsPocketedCount{White: 1}},
+	}
+
+	for _, c := range inputs {
+		if actual, err := c.Shot().Classify(); actual != c || err != nil {
+			t.Errorf("Shot(%v).Classify()= %v, err: %v, want= %v, err: nil", c, actual, err, c)
+		}
+	}
+}
+
+func TestContradictoryInput(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	for _, s := range []string{"0 b=5", "0", "0 b=1 red", "1 w=1", "2 b=1", "3 b=1", "5 red"} {
+		c, _ := carrom.ParseInput(s)
+
+		if _, err := g.PlayTurn(c); !errors.Is(err, carrom.ErrContradictoryInput) {
+			t.Errorf("PlayTurn(%q)= %v, want= %v", s, err, carrom.ErrContradictoryInput)
+		}
+	}
+
+	if res, err := g.PlayShot(carrom.Shot{Pocketed: carrom.CoinsPocketedCount{Black: 2, IsRedPocketed: true}}); err != nil || res.PointsDelta != 3 {
+		t.Errorf("PlayShot()= %+v, err: %v, want= red strike", res, err)
+	}
+
+	if c := g.Coins(); c != (carrom.Coins{White: 9, Black: 9}) {
+		t.Errorf("Coins() after red strike= %+v, want= only red pocketed", c)
+	}
+}
+
+func TestCompoundShot(t *testing.T) {
+	black := carrom.CoinsPocketedCount{Black: 1}
+
+	testCases := []struct {
+		shot            carrom.Shot
+		expectedStrikes []carrom.Input
+		expectedDelta   int
+		expectedCoins   carrom.Coins
+	}{
+		{
+			carrom.Shot{Pocketed: black, StrikerPocketed: true},
+			[]carrom.Input{{StrikeCode: 3}, {0, black}}, 0, carrom.Coins{Red: 1, White: 9, Black: 8},
+		},
+		{
+			carrom.Shot{Pocketed: carrom.CoinsPocketedCount{IsRedPocketed: true}, Thrown: black},
+			[]carrom.Input{{4, black}, {StrikeCode: 2}}, 1, carrom.Coins{White: 9, Black: 8},
+		},
+		{
+			carrom.Shot{StrikerPocketed: true, Thrown: carrom.CoinsPocketedCount{White: 2}},
+			[]carrom.Input{{4, carrom.CoinsPocketedCount{White: 2}}, {StrikeCode: 3}}, -3, carrom.Coins{Red: 1, White: 7, Black: 9},
+		},
+	}
+
+	for _, tc := range testCases {
+		g, _ := startGame(t, "p1", "p2")
+
+		res, err := g.PlayShot(tc.shot)
+		if err != nil || !reflect.DeepEqual(res.Strikes, tc.expectedStrikes) || res.Input != tc.expectedStrikes[0] {
+			t.Errorf("PlayShot(%+v)= %+v, err: %v, want= strikes %v", tc.shot, res, err, tc.expectedStrikes)
+		}
+
+		if res.PointsDelta != tc.expectedDelta || !res.Fouled || g.Players()[0].FoulCount != 1 {
+			t.Errorf("PlayShot(%+v)= delta: %d, fouled: %t, fouls: %d, want= delta: %d, one foul",
+				tc.shot, res.PointsDelta, res.Fouled, g.Players()[0].FoulCount, tc.expectedDelta)
+		}
+
+		if c := g.Coins(); c != tc.expectedCoins {
+			t.Errorf("PlayShot(%+v)= coins: %+v, want= %+v", tc.shot, c, tc.expectedCoins)
+		}
+
+		g.Close()
+	}
+}
+
+func TestCompoundShotInvalid(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	// a black coin thrown off leaves 8 black coins to be pocketed.
+	shot := carrom.Shot{Pocketed: carrom.CoinsPocketedCount{Black: 9}, Thrown: carrom.CoinsPocketedCount{Black: 1}}
+	if _, err := g.PlayShot(shot); err == nil {
+		t.Fatalf("PlayShot(%+v)= nil, want= error", shot)
+	}
+
+	if p, c := g.Players()[0], g.Coins(); p.Points != 0 || p.FoulCount != 0 || c.Black != 9 {
+		t.Errorf("PlayShot(%+v)= score: %d, fouls: %d, black coins: %d, want= nothing applied", shot, p.Points, p.FoulCount, c.Black)
+	}
+
+	if res, err := g.PlayTurn(carrom.Input{StrikeCode: 5}); err != nil || res.PlayerName != "p1" {
+		t.Errorf("PlayTurn() after invalid shot= %+v, err: %v, want= turn of p1", res, err)
+	}
+}
@@ -0,0 +1,119 @@
+package carrom
+
+// GameSnapshot is a consistent view of a game at one moment, see Game.Snapshot.
+type GameSnapshot struct {
+	State State
+	// Turn is count of turns played.
+	Turn int
+	// CurrentPlayer is the player to play the next turn, empty unless the game is in progress.
+	CurrentPlayer string
+	Players       []PlayerSnapshot
+	// Coins is coins left on board by colour.
+	Coins Coins
+}
+
+// PlayerSnapshot is a player in a snapshot of the game.
+type PlayerSnapshot struct {
+	PlayerName string
+	Points     int
+	// FoulCount is fouls counting to the next point lost, and Fouls is all fouls in the game.
+	FoulCount int
+	Fouls     int
+	// NoPocketCount is the streak of turns without pocketing a coin.
+	NoPocketCount int
+	Pocketed      Coins
+	Seat          Side `json:",omitempty"`
+	Eliminated    bool
+	// PointsToWin is points player needs to win if nobody else scores, 0 once he has won.
+	// It is not set for games in elimination mode, which are not won by a lead, and for
+	// eliminated players.
+	PointsToWin int
+	// Chances are chances of player to win, set if the game has a forecast.
+	Chances *Chances `json:",omitempty"`
+}
+
+// Snapshot returns state, turn, players and coins of the game taken together, so they agree
+// with each other. It is safe to call while turns are played. Chances of a game with a forecast
+// are estimated after the rest is taken, without holding up turns, and are of the same position.
+func (g *Game) Snapshot() GameSnapshot {
+	s, job := g.snapshot()
+	chances := job.run()
+
+	for i := range s.Players {
+		if c, ok := chances[s.Players[i].PlayerName]; ok {
+			s.Players[i].Chances = &c
+		}
+	}
+
+	return s
+}
+
+// snapshot returns snapshot of the game without chances, and job estimating them.
+func (g *Game) snapshot() (GameSnapshot, chancesJob) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	s := GameSnapshot{
+		State:   g.state,
+		Turn:    g.turnCount,
+		Players: make([]PlayerSnapshot, 0, len(g.players)),
+	}
+
+	if g.coins != nil {
+		s.Coins = *g.coins
+	}
+
+	if g.state == InProgress {
+		s.CurrentPlayer = g.currentPlayer().PlayerName
+	}
+
+	for _, p := range g.players {
+		s.Players = append(s.Players, PlayerSnapshot{
+			PlayerName:    p.PlayerName,
+			Points:        p.Points,
+			FoulCount:     p.FoulCount,
+			Fouls:         p.totalFouls(),
+			NoPocketCount: p.NoPocketCount,
+			Pocketed:      p.Pocketed,
+			Seat:          p.Seat,
+			Eliminated:    p.eliminated,
+			PointsToWin:   g.pointsToWin(p),
+		})
+	}
+
+	return s, g.chancesJob()
+}
+
+// Snapshot returns snapshot of the game started by NewBoard.
+func Snapshot() GameSnapshot {
+	return getDefaultGame().Snapshot()
+}
+
+// pointsToWin returns points p needs to win by a lead, as getWinner decides it.
+func (g *Game) pointsToWin(p *Player) int {
+	if g.elimination.Enabled || p.eliminated {
+		return 0
+	}
+
+	opposition := 0
+
+	for i, other := range g.ranked(p) {
+		switch {
+		case g.ranking.Victory == LeadOverAll:
+			opposition += other.Points
+		case i == 0 || other.Points > opposition:
+			opposition = other.Points
+		}
+	}
+
+	needed := opposition + p.leadNeeded() - p.Points
+	if pointsToWin-p.Points > needed {
+		needed = pointsToWin - p.Points
+	}
+
+	if needed < 0 {
+		return 0
+	}
+
+	return needed
+}
@@ -0,0 +1,104 @@
+package carrom_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestSnapshot(t *testing.T) {
+	g := carrom.NewGame("")
+	_ = g.AddPlayers([]string{"p1", "p2", "p3"})
+
+	if s := g.Snapshot(); s.State != carrom.Setup || s.CurrentPlayer != "" || len(s.Players) != 3 {
+		t.Errorf("Snapshot() before start= %+v, want= setup with 3 players", s)
+	}
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	playTurnsOn(t, g,
+		carrom.Input{StrikeCode: 2},
+		carrom.Input{StrikeCode: 5},
+		carrom.Input{StrikeCode: 3},
+	)
+
+	s := g.Snapshot()
+	if s.State != carrom.InProgress || s.Turn != 3 || s.CurrentPlayer != "p1" || s.Coins != (carrom.Coins{White: 9, Black: 9}) {
+		t.Errorf("Snapshot()= %+v, want= p1 to play turn 4 with red pocketed", s)
+	}
+
+	expected := []carrom.PlayerSnapshot{
+		{PlayerName: "p1", Points: 3, Pocketed: carrom.Coins{Red: 1}, Seat: carrom.SideSouth, PointsToWin: 2},
+		{PlayerName: "p2", NoPocketCount: 1, Seat: carrom.SideEast, PointsToWin: 6},
+		{PlayerName: "p3", Points: -1, FoulCount: 1, Fouls: 1, Seat: carrom.SideNorth, PointsToWin: 7},
+	}
+
+	for i, p := range s.Players {
+		if p != expected[i] {
+			t.Errorf("Snapshot().Players[%d]= %+v, want= %+v", i, p, expected[i])
+		}
+	}
+
+	if _, err := json.Marshal(s); err != nil {
+		t.Errorf("Marshal(Snapshot())= %v, want= nil", err)
+	}
+}
+
+func TestSnapshotLeadOverAll(t *testing.T) {
+	g := carrom.NewGame("")
+	_ = g.SetRanking(carrom.Ranking{Victory: carrom.LeadOverAll})
+	_ = g.AddPlayers([]string{"p1", "p2", "p3"})
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	defer g.Close()
+
+	playTurnsOn(t, g,
+		carrom.Input{StrikeCode: 0, CoinsPocketedCount: carrom.CoinsPocketedCount{Black: 1}},
+		carrom.Input{StrikeCode: 2},
+		carrom.Input{StrikeCode: 0, CoinsPocketedCount: carrom.CoinsPocketedCount{White: 1}},
+	)
+
+	for i, expected := range []int{6, 2, 6} {
+		if actual := g.Snapshot().Players[i].PointsToWin; actual != expected {
+			t.Errorf("Snapshot().Players[%d].PointsToWin= %d, want= %d", i, actual, expected)
+		}
+	}
+}
+
+func TestSnapshotConcurrent(t *testing.T) {
+	g, input := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 50; i++ {
+			s := g.Snapshot()
+
+			if held := s.Coins.Black + s.Players[0].Pocketed.Black + s.Players[1].Pocketed.Black; held != 9 {
+				t.Errorf("Snapshot()= %+v, want= 9 black coins on board and held", s)
+
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 8; i++ {
+		input <- carrom.Input{StrikeCode: 0, CoinsPocketedCount: carrom.CoinsPocketedCount{Black: 1}}
+	}
+
+	wg.Wait()
+}
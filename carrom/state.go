@@ -0,0 +1,180 @@
+package carrom
+
+import (
+	"errors"
+	"fmt"
+)
+
+// State is where a game is in its life. A game is set up with its players, then
+// it is in progress until it is finished by a winner, drawn or abandoned.
+type State int
+
+const (
+	// Setup games take players and wait to be started.
+	Setup State = iota
+	// InProgress games take turns.
+	InProgress
+	// Finished games have a winner.
+	Finished
+	// Drawn games ended without a winner.
+	Drawn
+	// Abandoned games were left before they were over.
+	Abandoned
+)
+
+var stateNames = map[State]string{
+	Setup:      "setup",
+	InProgress: "in progress",
+	Finished:   "finished",
+	Drawn:      "drawn",
+	Abandoned:  "abandoned",
+}
+
+func (s State) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// MarshalText encodes state by its name.
+func (s State) MarshalText() ([]byte, error) {
+	if _, ok := stateNames[s]; !ok {
+		return nil, fmt.Errorf("invalid state %d", int(s))
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes state from its name.
+func (s *State) UnmarshalText(text []byte) error {
+	for state, name := range stateNames {
+		if name == string(text) {
+			*s = state
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid state %q", text)
+}
+
+// IsOver returns true for the states a game ends in.
+func (s State) IsOver() bool {
+	return s == Finished || s == Drawn || s == Abandoned
+}
+
+// ErrInvalidState is returned, wrapped with the operation tried and the state of the game,
+// when the operation is not allowed in the state the game is in.
+var ErrInvalidState = errors.New("not allowed in current state")
+
+// allow returns an error if game is not in one of the states.
+// It must be called holding lock of the game.
+func (g *Game) allow(operation string, states ...State) error {
+	for _, s := range states {
+		if g.state == s {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s: game is %s: %w", operation, g.state, ErrInvalidState)
+}
+
+// State returns the state game is in.
+func (g *Game) State() State {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.state
+}
+
+// savedTurn is what a turn changes, kept to undo the turn.
+type savedTurn struct {
+	players         []Player
+	coins           Coins
+	playerIDForTurn int
+	turnCount       int
+	eliminated      int
+	rounds          int
+	capTurn         int
+	deciding        bool
+	decidingFrom    int
+}
+
+func (g *Game) saveTurn() {
+	saved := savedTurn{
+		players:         make([]Player, 0, len(g.players)),
+		coins:           *g.coins,
+		playerIDForTurn: g.playerIDForTurn,
+		turnCount:       g.turnCount,
+		eliminated:      len(g.eliminated),
+		rounds:          g.rounds,
+		capTurn:         g.capTurn,
+		deciding:        g.deciding,
+		decidingFrom:    g.decidingFrom,
+	}
+
+	for _, p := range g.players {
+		saved.players = append(saved.players, *p)
+	}
+
+	g.history = append(g.history, saved)
+	g.newPosition()
+}
+
+// Undo takes back the last turn, including turns recorded by the shot clock.
+// A game which was finished or drawn by the turn is in progress again.
+func (g *Game) Undo() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("undo", InProgress, Finished, Drawn); err != nil {
+		return err
+	}
+
+	if len(g.history) == 0 {
+		return fmt.Errorf("undo: no turn is played yet")
+	}
+
+	if g.clock.isTimeUp() {
+		return fmt.Errorf("undo: game is over as time is up")
+	}
+
+	if g.clock != nil && !g.clock.stopped {
+		g.clock.turnPlayed()
+	}
+
+	g.restoreTurn()
+	g.turns = g.turns[:len(g.turns)-1]
+	g.state = InProgress
+
+	if g.clock != nil {
+		g.clock.stopped = false
+		g.clock.turnStarted()
+	}
+
+	return nil
+}
+
+// restoreTurn takes back what was saved by the last saveTurn, except the turns recorded.
+func (g *Game) restoreTurn() {
+	saved := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	g.newPosition()
+
+	for i, p := range g.players {
+		board := p.board
+		*p = saved.players[i]
+		p.board = board
+	}
+
+	*g.coins = saved.coins
+	g.playerIDForTurn = saved.playerIDForTurn
+	g.turnCount = saved.turnCount
+	g.eliminated = g.eliminated[:saved.eliminated]
+	g.rounds = saved.rounds
+	g.capTurn = saved.capTurn
+	g.deciding = saved.deciding
+	g.decidingFrom = saved.decidingFrom
+}
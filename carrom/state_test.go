@@ -0,0 +1,228 @@
+package carrom_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func startGame(t *testing.T, names ...string) (*carrom.Game, chan carrom.Input) {
+	t.Helper()
+
+	g := carrom.NewGame("")
+	if err := g.AddPlayers(names); err != nil {
+		t.Fatalf("AddPlayers(%v)= %v, want= nil", names, err)
+	}
+
+	input, err := g.Start()
+	if err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	return g, input
+}
+
+// win gives p1 a 5-0 lead, p2 pocketing no coin in between.
+var win = []carrom.Input{
+	{StrikeCode: 2}, {StrikeCode: 5},
+	{StrikeCode: 1, CoinsPocketedCount: carrom.CoinsPocketedCount{Black: 2}},
+}
+
+func TestGameStates(t *testing.T) {
+	g := carrom.NewGame("")
+
+	if _, err := g.Start(); err == nil {
+		t.Errorf("Start() without players= nil, want= error")
+	}
+
+	if s := g.State(); s != carrom.Setup {
+		t.Errorf("State()= %v, want= %v", s, carrom.Setup)
+	}
+
+	if _, err := g.PlayTurn(carrom.Input{StrikeCode: 5}); !errors.Is(err, carrom.ErrInvalidState) {
+		t.Errorf("PlayTurn() in setup= %v, want= %v", err, carrom.ErrInvalidState)
+	}
+
+	g, input := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	if err := g.AddPlayers([]string{"p3", "p4"}); !errors.Is(err, carrom.ErrInvalidState) {
+		t.Errorf("AddPlayers() in progress= %v, want= %v", err, carrom.ErrInvalidState)
+	}
+
+	for _, c := range win {
+		if _, err := g.PlayTurn(c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	if s := g.State(); s != carrom.Finished || !g.IsOver() || !g.IsOver() {
+		t.Errorf("State()= %v, want= %v", s, carrom.Finished)
+	}
+
+	if _, err := g.PlayTurn(carrom.Input{StrikeCode: 5}); !errors.Is(err, carrom.ErrInvalidState) {
+		t.Errorf("PlayTurn() after game is over= %v, want= %v", err, carrom.ErrInvalidState)
+	}
+
+	// input sent after the game is over is dropped, not panicking.
+	input <- carrom.Input{StrikeCode: 5}
+
+	if err := g.Abandon(); !errors.Is(err, carrom.ErrInvalidState) {
+		t.Errorf("Abandon() of finished game= %v, want= %v", err, carrom.ErrInvalidState)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	if err := g.Undo(); err == nil {
+		t.Errorf("Undo() before any turn= nil, want= error")
+	}
+
+	for _, c := range win {
+		if _, err := g.PlayTurn(c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo() of winning turn= %v, want= nil", err)
+	}
+
+	if s := g.State(); s != carrom.InProgress || g.Winner() != "" {
+		t.Errorf("State() after Undo()= %v, winner: %q, want= %v, no winner", s, g.Winner(), carrom.InProgress)
+	}
+
+	if p := g.Players()[0]; p.Points != 3 {
+		t.Errorf("Undo()= p1 score: %d, want= 3", p.Points)
+	}
+
+	if c := g.Coins(); c.Black != 9 || len(g.Turns()) != 2 {
+		t.Errorf("Undo()= black coins: %d, turns: %d, want= 9, 2", c.Black, len(g.Turns()))
+	}
+
+	res, err := g.PlayTurn(carrom.Input{StrikeCode: 5})
+	if err != nil || res.PlayerName != "p1" {
+		t.Errorf("PlayTurn() after Undo()= %+v, err: %v, want= turn of p1", res, err)
+	}
+}
+
+func TestClosedGamesStopReadingInput(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 50; i++ {
+		g, _ := startGame(t, "p1", "p2")
+
+		for _, c := range win {
+			if _, err := g.PlayTurn(c); err != nil {
+				t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+			}
+		}
+
+		g.Close()
+	}
+
+	// readers of input exit soon after their game is closed.
+	for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > before+5 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if n := runtime.NumGoroutine(); n > before+5 {
+		t.Errorf("NumGoroutine() after 50 closed games= %d, want= about %d", n, before)
+	}
+}
+
+func TestInputAfterGameOver(t *testing.T) {
+	g, input := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	for _, c := range win {
+		if _, err := g.PlayTurn(c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	sent := make(chan struct{})
+
+	go func() {
+		input <- carrom.Input{StrikeCode: 5}
+		input <- carrom.Input{StrikeCode: 5}
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatalf("sending two inputs after game is over blocked")
+	}
+
+	if n := len(g.Turns()); n != 3 || g.State() != carrom.Finished {
+		t.Errorf("game after input sent when over= %s with %d turns, want= finished with 3", g.State(), n)
+	}
+}
+
+func TestUndoReadsInputAgain(t *testing.T) {
+	g, input := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	for _, c := range win {
+		if _, err := g.PlayTurn(c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo()= %v, want= nil", err)
+	}
+
+	input <- carrom.Input{StrikeCode: 5}
+
+	for deadline := time.Now().Add(time.Second); len(g.Turns()) != 3 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if n := len(g.Turns()); n != 3 {
+		t.Errorf("Turns() after input sent to game undone= %d, want= 3", n)
+	}
+}
+
+func TestRulesAreSetInSetup(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	setters := map[string]func() error{
+		"SetRanking":       func() error { return g.SetRanking(carrom.Ranking{Victory: carrom.LeadOverAll}) },
+		"SetElimination":   func() error { return g.SetElimination(carrom.Elimination{Enabled: true}) },
+		"SetTurnCap":       func() error { return g.SetTurnCap(carrom.TurnCap{MaxTurns: 1}) },
+		"SetDrawBreak":     func() error { return g.SetDrawBreak(carrom.DrawBreak{Procedure: carrom.Shootout}) },
+		"SetFoulPenalties": func() error { return g.SetFoulPenalties(nil) },
+		"SetFoulDues":      func() error { return g.SetFoulDues(true) },
+	}
+
+	for name, set := range setters {
+		if err := set(); !errors.Is(err, carrom.ErrInvalidState) {
+			t.Errorf("%s() of game in progress= %v, want= %v", name, err, carrom.ErrInvalidState)
+		}
+	}
+}
+
+func TestAbandon(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	if err := g.Abandon(); err != nil {
+		t.Fatalf("Abandon()= %v, want= nil", err)
+	}
+
+	if s := g.State(); s != carrom.Abandoned || !g.IsOver() {
+		t.Errorf("State()= %v, want= %v", s, carrom.Abandoned)
+	}
+
+	if err := g.Undo(); !errors.Is(err, carrom.ErrInvalidState) {
+		t.Errorf("Undo() of abandoned game= %v, want= %v", err, carrom.ErrInvalidState)
+	}
+}
@@ -7,6 +7,7 @@ import (
 )
 
 // Strike adds a point to player removes the pocketed coin out of game.
+// An error is returned if no coin is pocketed or the coin is not on board.
 func (p *Player) Strike(coinsPocketed CoinsPocketedCount) error {
 	if coinsPocketed.Black < 1 && coinsPocketed.White < 1 {
 		l.WithField("coinsPocketedCount", coinsPocketed).Errorln("invalid request. Ignoring request")
@@ -14,10 +15,21 @@ func (p *Player) Strike(coinsPocketed CoinsPocketedCount) error {
 		return fmt.Errorf(invalid)
 	}
 
+	coins := p.coins()
+
+	if coinsPocketed.Black > coins.Black || coinsPocketed.White > coins.White {
+		l.WithFields(l.Fields{
+			"coinsOnBoard":        *coins,
+			"coinsCountRequested": coinsPocketed,
+		}).Errorln("invalid strike request. Ignoring request")
+
+		return fmt.Errorf(invalid)
+	}
+
 	p.Points++
 
-	removeCoin(black, coinsPocketed.Black)
-	removeCoin(white, coinsPocketed.White)
+	p.pocket(black, coinsPocketed.Black)
+	p.pocket(white, coinsPocketed.White)
 
 	return nil
 }
@@ -25,11 +37,13 @@ func (p *Player) Strike(coinsPocketed CoinsPocketedCount) error {
 // MultiStrike takes count of coins pocketed and a flag to determine red coin is pocketed.
 // And error is returned incase of invalid coins count or red pocketed flag.
 func (p *Player) MultiStrike(coinsPocketed CoinsPocketedCount) error {
-	if coinsPocketed.Black > CoinsOnBoard.Black || coinsPocketed.White > CoinsOnBoard.White ||
+	coins := p.coins()
+
+	if coinsPocketed.Black > coins.Black || coinsPocketed.White > coins.White ||
 		(coinsPocketed.Black == 0 && coinsPocketed.White == 0) ||
-		(coinsPocketed.IsRedPocketed && CoinsOnBoard.Red == 0) {
+		(coinsPocketed.IsRedPocketed && coins.Red == 0) {
 		l.WithFields(l.Fields{
-			"blackCoinOnBoard":    *CoinsOnBoard,
+			"blackCoinOnBoard":    *coins,
 			"coinsCountRequested": coinsPocketed,
 		}).Errorln("invalid multistrike request. Ignoring request")
 
@@ -40,25 +54,29 @@ func (p *Player) MultiStrike(coinsPocketed CoinsPocketedCount) error {
 	p.Points += 2
 
 	if coinsPocketed.IsRedPocketed {
-		removeCoin(red, 1)
+		p.redPocketed++
+		p.pocket(red, 1)
 	}
 
-	removeCoin(black, coinsPocketed.Black)
-	removeCoin(white, coinsPocketed.White)
+	p.pocket(black, coinsPocketed.Black)
+	p.pocket(white, coinsPocketed.White)
 
 	return nil
 }
 
 // RedStrike returns error if red coin is already out of game.
 func (p *Player) RedStrike() error {
-	if CoinsOnBoard.Red <= 0 {
+	coins := p.coins()
+
+	if coins.Red <= 0 {
 		l.Errorln("red coin is not in board. Ignoring request.")
 
 		return fmt.Errorf(invalid)
 	}
 
-	p.Points += 3
-	removeCoin(red, 1)
+	p.Points += redPoints + p.Handicap.RedBonus
+	p.redPocketed++
+	p.pocket(red, 1)
 
 	return nil
 }
@@ -66,18 +84,37 @@ func (p *Player) RedStrike() error {
 // StrikerStrike adds a foul count as player loses a point.
 func (p *Player) StrikerStrike() {
 	p.Points--
-	p.foul()
+	p.foul(FoulStriker, 1)
+}
+
+// ShotClockFoul takes a point from player who ran out of shot clock and counts it as foul.
+func (p *Player) ShotClockFoul() {
+	p.Points--
+	p.foul(FoulShotClock, 1)
 }
 
 // Defunct takes count of coins pocketed and a flag to determine red coin is pocketed
 // and removes coins out of game provided in.
 // An error is returned incase of invalid coins count or red pocketed flag.
 func (p *Player) Defunct(coinsPocketed CoinsPocketedCount) error {
+	if err := p.defunct(coinsPocketed); err != nil {
+		return err
+	}
+
+	p.foul(FoulDefunct, 2)
+
+	return nil
+}
+
+// defunct is Defunct without counting the foul.
+func (p *Player) defunct(coinsPocketed CoinsPocketedCount) error {
+	coins := p.coins()
+
 	if (coinsPocketed.Black == 0 && coinsPocketed.White == 0 && !coinsPocketed.IsRedPocketed) ||
-		coinsPocketed.Black > CoinsOnBoard.Black || coinsPocketed.White > CoinsOnBoard.White ||
-		(coinsPocketed.IsRedPocketed && CoinsOnBoard.Red == 0) {
+		coinsPocketed.Black > coins.Black || coinsPocketed.White > coins.White ||
+		(coinsPocketed.IsRedPocketed && coins.Red == 0) {
 		l.WithFields(l.Fields{
-			"blackCoinOnBoard":    *CoinsOnBoard,
+			"blackCoinOnBoard":    *coins,
 			"coinsCountRequested": coinsPocketed,
 		}).Errorln("invalid defunct request. Ignoring request")
 
@@ -85,14 +122,81 @@ func (p *Player) Defunct(coinsPocketed CoinsPocketedCount) error {
 	}
 
 	p.Points -= 2
-	p.foul()
 
 	if coinsPocketed.IsRedPocketed {
-		removeCoin(red, 1)
+		coins.remove(red, 1)
+	}
+
+	coins.remove(black, coinsPocketed.Black)
+	coins.remove(white, coinsPocketed.White)
+
+	return nil
+}
+
+// play applies strike c to player.
+func (p *Player) play(c Input) error {
+	switch c.StrikeCode {
+	case 0:
+		return p.Strike(c.CoinsPocketedCount)
+	case 1:
+		return p.MultiStrike(c.CoinsPocketedCount)
+	case 2:
+		return p.RedStrike()
+	case 3:
+		p.StrikerStrike()
+	case 4:
+		return p.Defunct(c.CoinsPocketedCount)
+	case 5:
+		p.NoPocket()
+	default:
+		l.WithField("strikeCode", c.StrikeCode).Errorln("invalid strike code")
+
+		return fmt.Errorf(invalid)
+	}
+
+	return nil
+}
+
+// playCompound applies strikes of a compound shot to player in the order given.
+// Points of every strike are counted, but the turn is counted as one foul of the type of
+// the first foul strike.
+func (p *Player) playCompound(strikes []Input) error {
+	var f FoulType
+
+	penalty := 0
+
+	for _, c := range strikes {
+		var err error
+
+		switch c.StrikeCode {
+		case 3:
+			p.Points--
+			penalty++
+
+			if f == 0 {
+				f = FoulStriker
+			}
+		case 4:
+			err = p.defunct(c.CoinsPocketedCount)
+			penalty += 2
+
+			if f == 0 {
+				f = FoulDefunct
+			}
+		case 5:
+			err = fmt.Errorf("no coin pocketed can't be part of a compound shot")
+		default:
+			err = p.play(c)
+		}
+
+		if err != nil {
+			return err
+		}
 	}
 
-	removeCoin(black, coinsPocketed.Black)
-	removeCoin(white, coinsPocketed.White)
+	if f != 0 {
+		p.foul(f, penalty)
+	}
 
 	return nil
 }
@@ -103,17 +207,19 @@ func (p *Player) NoPocket() {
 
 	if p.NoPocketCount >= 3 {
 		p.Points--
-		p.foul()
+		p.foul(FoulNoPocket, 1)
 		p.NoPocketCount = 0
 	}
 }
 
-// ​foul is a turn where a player loses, at least, 1 point.
-// player loses a point on three fouls.
-func (p *Player) foul() {
+// ​foul is a turn where a player loses, at least, 1 point. It is added to foul history
+// of player with the penalty taken for it.
+// player loses a point on three fouls, or more if his handicap allows extra fouls.
+func (p *Player) foul(f FoulType, penalty int) {
 	p.FoulCount++
+	p.FoulHistory = append(p.FoulHistory, Foul{Type: f, Penalty: penalty})
 
-	if p.FoulCount >= 3 {
+	if p.FoulCount >= foulsAllowed+p.Handicap.ExtraFouls {
 		p.Points--
 		p.FoulCount = 0
 	}
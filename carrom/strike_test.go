@@ -3,7 +3,6 @@ package carrom_test
 import (
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/RenugaParamalingam/carrom/carrom"
 )
@@ -194,42 +193,55 @@ func TestIsGameOver(t *testing.T) {
 func setGameInDraw() func() {
 	return func() {
 		carrom.AddPlayersToGame([]string{"p1", "p2"})
-		strikeInput := carrom.NewBoard()
-
-		strikeInput <- carrom.Input{StrikeCode: 0}
-		strikeInput <- carrom.Input{1, carrom.CoinsPocketedCount{Black: 5, IsRedPocketed: false}}
-		strikeInput <- carrom.Input{StrikeCode: 2}
-		strikeInput <- carrom.Input{StrikeCode: 3}
-		strikeInput <- carrom.Input{4, carrom.CoinsPocketedCount{Black: 2, White: 8, IsRedPocketed: false}}
-		strikeInput <- carrom.Input{StrikeCode: 5}
-		strikeInput <- carrom.Input{0, carrom.CoinsPocketedCount{Black: 2, White: 4, IsRedPocketed: false}}
-		time.Sleep(time.Second * 1)
+		carrom.NewBoard()
+
+		playTurns(
+			carrom.Input{StrikeCode: 0},
+			carrom.Input{1, carrom.CoinsPocketedCount{Black: 5, IsRedPocketed: false}},
+			carrom.Input{StrikeCode: 2},
+			carrom.Input{StrikeCode: 3},
+			carrom.Input{4, carrom.CoinsPocketedCount{Black: 2, White: 8, IsRedPocketed: false}},
+			carrom.Input{StrikeCode: 5},
+			carrom.Input{1, carrom.CoinsPocketedCount{Black: 2, White: 1, IsRedPocketed: false}},
+		)
 	}
 }
 
 func setGameInWin() func() {
 	return func() {
 		carrom.AddPlayersToGame([]string{"p3", "p4"})
-		strikeInput := carrom.NewBoard()
-
-		strikeInput <- carrom.Input{1, carrom.CoinsPocketedCount{Black: 5, IsRedPocketed: false}}
-		strikeInput <- carrom.Input{0, carrom.CoinsPocketedCount{Black: 2, White: 4, IsRedPocketed: false}}
-		strikeInput <- carrom.Input{StrikeCode: 2}
-		strikeInput <- carrom.Input{StrikeCode: 3}
-		strikeInput <- carrom.Input{StrikeCode: 0}
-		strikeInput <- carrom.Input{StrikeCode: 5}
-		strikeInput <- carrom.Input{0, carrom.CoinsPocketedCount{Black: 2, White: 4, IsRedPocketed: false}}
+		carrom.NewBoard()
+
+		playTurns(
+			carrom.Input{1, carrom.CoinsPocketedCount{Black: 5, IsRedPocketed: false}},
+			carrom.Input{0, carrom.CoinsPocketedCount{Black: 1, IsRedPocketed: false}},
+			carrom.Input{StrikeCode: 2},
+			carrom.Input{StrikeCode: 3},
+			carrom.Input{StrikeCode: 0},
+			carrom.Input{StrikeCode: 5},
+			carrom.Input{0, carrom.CoinsPocketedCount{Black: 1, IsRedPocketed: false}},
+		)
 	}
 }
 
 func setGameUnFinished() func() {
 	return func() {
 		carrom.AddPlayersToGame([]string{"p3", "p4"})
-		strikeInput := carrom.NewBoard()
+		carrom.NewBoard()
 
-		strikeInput <- carrom.Input{1, carrom.CoinsPocketedCount{Black: 5, IsRedPocketed: false}}
-		strikeInput <- carrom.Input{StrikeCode: 0}
-		strikeInput <- carrom.Input{StrikeCode: 2}
+		playTurns(
+			carrom.Input{1, carrom.CoinsPocketedCount{Black: 5, IsRedPocketed: false}},
+			carrom.Input{StrikeCode: 0},
+			carrom.Input{StrikeCode: 2},
+		)
+	}
+}
+
+// playTurns applies inputs one after another. Invalid inputs are ignored
+// the same way they are when fed through StrikeCodeInput.
+func playTurns(inputs ...carrom.Input) {
+	for _, c := range inputs {
+		_, _ = carrom.PlayTurn(c)
 	}
 }
 
@@ -265,3 +277,69 @@ func TestStrike(t *testing.T) {
 		}
 	}
 }
+
+func TestPocketed(t *testing.T) {
+	g, _ := startGame(t, "p1", "p2")
+	defer g.Close()
+
+	playTurnsOn(t, g,
+		carrom.Input{1, carrom.CoinsPocketedCount{Black: 1, White: 1, IsRedPocketed: true}},
+		carrom.Input{0, carrom.CoinsPocketedCount{White: 1}},
+		carrom.Input{4, carrom.CoinsPocketedCount{Black: 2}},
+		carrom.Input{StrikeCode: 3},
+	)
+
+	testCases := []struct {
+		expectedP1 carrom.Coins
+		expectedP2 carrom.Coins
+	}{
+		{carrom.Coins{Red: 1, Black: 1, White: 1}, carrom.Coins{White: 1}},
+		{carrom.Coins{Red: 1, Black: 1, White: 1}, carrom.Coins{White: 1}},
+		{carrom.Coins{Red: 1, Black: 1, White: 1}, carrom.Coins{White: 1}},
+		{carrom.Coins{Red: 1, Black: 1, White: 1}, carrom.Coins{}},
+		{carrom.Coins{}, carrom.Coins{}},
+	}
+
+	for _, tc := range testCases {
+		players := g.Players()
+
+		if players[0].Pocketed != tc.expectedP1 || players[1].Pocketed != tc.expectedP2 {
+			t.Errorf("Pocketed after %d turns= %+v, %+v, want= %+v, %+v",
+				len(g.Turns()), players[0].Pocketed, players[1].Pocketed, tc.expectedP1, tc.expectedP2)
+		}
+
+		_ = g.Undo()
+	}
+}
+
+func TestStrikeCoinNotOnBoard(t *testing.T) {
+	g := carrom.NewGame("")
+	pos := carrom.Position{
+		Coins: carrom.Coins{White: 3},
+		Players: []carrom.PlayerPosition{
+			{PlayerName: "p1", Pocketed: carrom.Coins{Red: 1, Black: 9}},
+			{PlayerName: "p2", Pocketed: carrom.Coins{White: 6}},
+		},
+	}
+
+	if err := g.SetPosition(pos); err != nil {
+		t.Fatalf("SetPosition()= %v, want= nil", err)
+	}
+
+	if _, err := g.Start(); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+	defer g.Close()
+
+	if res, err := g.PlayTurn(carrom.Input{0, carrom.CoinsPocketedCount{Black: 1}}); err == nil {
+		t.Errorf("PlayTurn() of black strike without black on board= %+v, want= error", res)
+	}
+
+	if res, err := g.PlayShot(carrom.Shot{Pocketed: carrom.CoinsPocketedCount{Black: 1}}); err == nil {
+		t.Errorf("PlayShot() of black pocketed without black on board= %+v, want= error", res)
+	}
+
+	if c, p := g.Coins(), g.Players()[0]; c != pos.Coins || p.Points != 0 || len(g.Turns()) != 0 {
+		t.Errorf("Coins()= %+v, p1 points: %d, turns: %d, want= %+v, 0, 0", c, p.Points, len(g.Turns()), pos.Coins)
+	}
+}
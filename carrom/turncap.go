@@ -0,0 +1,132 @@
+package carrom
+
+import "fmt"
+
+// Adjudication decides a game which reached its turn cap without being over.
+type Adjudication int
+
+const (
+	// AdjudicateDraw ends the game in draw.
+	AdjudicateDraw Adjudication = iota
+	// AdjudicateLeader wins the game for the leader, see Ranking. The game is drawn
+	// if the leader is tied.
+	AdjudicateLeader
+	// AdjudicateSuddenDeath plays rounds of one turn for every player until a round ends
+	// with a leader who is not tied. The game is drawn if there is none after SuddenDeathRounds.
+	AdjudicateSuddenDeath
+)
+
+// TurnCap limits number of turns of a game so it is guaranteed to end. A round is a turn
+// for every player in game.
+type TurnCap struct {
+	// MaxTurns caps turns of a game. 0 is no cap.
+	MaxTurns int
+	// MaxRounds caps rounds of a game. 0 is no cap.
+	MaxRounds    int
+	Adjudication Adjudication
+	// SuddenDeathRounds is most sudden-death rounds played. 0 is one round.
+	SuddenDeathRounds int
+}
+
+func (tc TurnCap) validate() error {
+	if tc.MaxTurns < 0 || tc.MaxRounds < 0 || tc.SuddenDeathRounds < 0 ||
+		tc.Adjudication < AdjudicateDraw || tc.Adjudication > AdjudicateSuddenDeath {
+		return fmt.Errorf("invalid turn cap %+v", tc)
+	}
+
+	return nil
+}
+
+func (tc TurnCap) reason() string {
+	if tc.Adjudication == AdjudicateSuddenDeath {
+		return ReasonSuddenDeath
+	}
+
+	return ReasonTurnCap
+}
+
+// turnCap is the turn cap new games start with.
+var turnCap TurnCap
+
+// SetTurnCap sets turn cap of the games created after it by NewBoard or NewGame.
+// Pass TurnCap{} to play without cap.
+func SetTurnCap(tc TurnCap) error {
+	if err := tc.validate(); err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	turnCap = tc
+
+	return nil
+}
+
+// SetTurnCap sets turn cap of a game being set up.
+func (g *Game) SetTurnCap(tc TurnCap) error {
+	if err := tc.validate(); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.allow("set turn cap", Setup); err != nil {
+		return err
+	}
+
+	g.turnCap = tc
+
+	return nil
+}
+
+// adjudicate decides a game which reached its turn cap. decided is false until the cap
+// is reached and while sudden-death rounds are played. winner is nil for a drawn game.
+func (g *Game) adjudicate() (winner *Player, decided bool) {
+	tc := g.turnCap
+
+	if g.capTurn == 0 {
+		if (tc.MaxTurns == 0 || g.turnCount < tc.MaxTurns) && (tc.MaxRounds == 0 || g.rounds < tc.MaxRounds) {
+			return nil, false
+		}
+
+		g.capTurn = g.turnCount
+
+		g.log().WithField("turn", g.turnCount).Warnln("turn cap reached")
+	}
+
+	switch tc.Adjudication {
+	case AdjudicateLeader:
+		return g.leader(), true
+	case AdjudicateSuddenDeath:
+		played, round := g.turnCount-g.capTurn, len(g.ranked(nil))
+		if played == 0 || played%round != 0 {
+			return nil, false
+		}
+
+		if leader := g.leader(); leader != nil {
+			return leader, true
+		}
+
+		rounds := tc.SuddenDeathRounds
+		if rounds == 0 {
+			rounds = 1
+		}
+
+		return nil, played/round >= rounds
+	default:
+		return nil, true
+	}
+}
+
+// leader returns the player placed first, or nil if he is tied with the player after him.
+func (g *Game) leader() *Player {
+	players := g.ranked(nil)
+
+	if len(players) == 1 || g.ranking.compare(players[0], players[1]) < 0 {
+		return players[0]
+	}
+
+	return nil
+}
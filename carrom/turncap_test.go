@@ -0,0 +1,74 @@
+package carrom_test
+
+import (
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+func TestTurnCap(t *testing.T) {
+	miss := carrom.Input{StrikeCode: 5}
+
+	testCases := []struct {
+		turnCap        carrom.TurnCap
+		inputs         []carrom.Input
+		expectedKind   carrom.OutcomeKind
+		expectedWinner string
+		expectedReason string
+	}{
+		{carrom.TurnCap{MaxTurns: 2}, []carrom.Input{{StrikeCode: 2}, miss}, carrom.OutcomeDrawn, "", carrom.ReasonTurnCap},
+		{carrom.TurnCap{MaxTurns: 2, Adjudication: carrom.AdjudicateLeader}, []carrom.Input{{StrikeCode: 2}, miss}, carrom.OutcomeWon, "p1", carrom.ReasonTurnCap},
+		{carrom.TurnCap{MaxRounds: 1, Adjudication: carrom.AdjudicateLeader}, []carrom.Input{miss, miss}, carrom.OutcomeDrawn, "", carrom.ReasonTurnCap},
+		{
+			carrom.TurnCap{MaxRounds: 1, Adjudication: carrom.AdjudicateSuddenDeath},
+			[]carrom.Input{miss, miss, {0, b(1)}, miss},
+			carrom.OutcomeWon, "p1", carrom.ReasonSuddenDeath,
+		},
+		{
+			carrom.TurnCap{MaxTurns: 2, Adjudication: carrom.AdjudicateSuddenDeath, SuddenDeathRounds: 2},
+			[]carrom.Input{miss, miss, miss, miss, miss, miss},
+			carrom.OutcomeDrawn, "", carrom.ReasonSuddenDeath,
+		},
+	}
+
+	for _, tc := range testCases {
+		g := carrom.NewGame("")
+		if err := g.SetTurnCap(tc.turnCap); err != nil {
+			t.Fatalf("SetTurnCap()= %v, want= nil", err)
+		}
+
+		if err := g.AddPlayers([]string{"p1", "p2"}); err != nil {
+			t.Fatalf("AddPlayers()= %v, want= nil", err)
+		}
+
+		if _, err := g.Start(); err != nil {
+			t.Fatalf("Start()= %v, want= nil", err)
+		}
+
+		for i, c := range tc.inputs {
+			if g.IsOver() {
+				t.Fatalf("IsOver() with %+v after %d turns= true, want= false", tc.turnCap, i)
+			}
+
+			if _, err := g.PlayTurn(c); err != nil {
+				t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+			}
+		}
+
+		o := g.Outcome()
+		if o.Kind != tc.expectedKind || o.Winner != tc.expectedWinner || o.Reason != tc.expectedReason {
+			t.Errorf("Outcome() with %+v= %v, %q, %q, want= %v, %q, %q",
+				tc.turnCap, o.Kind, o.Winner, o.Reason, tc.expectedKind, tc.expectedWinner, tc.expectedReason)
+		}
+
+		g.Close()
+	}
+}
+
+func TestSetTurnCapInvalid(t *testing.T) {
+	for _, tc := range []carrom.TurnCap{{MaxTurns: -1}, {MaxRounds: -1}, {SuddenDeathRounds: -1}, {Adjudication: 3}} {
+		if err := carrom.SetTurnCap(tc); err == nil {
+			t.Errorf("SetTurnCap(%+v)= nil, want= error", tc)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package gym
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Batch is environments stepped together, every one in its own goroutine. Environments whose
+// game is over are reset by the step ending it, so every step of a batch steps all of them.
+type Batch struct {
+	envs []*Env
+	// next is seed of the next game reset by a step.
+	next int64
+}
+
+// NewBatch returns a batch of n environments. It must be reset before its first step.
+func NewBatch(n int, c Config) (*Batch, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid batch size %d", n)
+	}
+
+	b := &Batch{envs: make([]*Env, n)}
+
+	for i := range b.envs {
+		e, err := New(c)
+		if err != nil {
+			return nil, err
+		}
+
+		b.envs[i] = e
+	}
+
+	return b, nil
+}
+
+// Len returns count of environments of the batch.
+func (b *Batch) Len() int {
+	return len(b.envs)
+}
+
+// Reset resets environment i of the batch with seed seed+i, and returns their observations.
+// Games reset by steps are seeded by the seeds following them.
+func (b *Batch) Reset(seed int64) ([][]float64, error) {
+	observations := make([][]float64, len(b.envs))
+
+	err := b.each(func(i int, e *Env) error {
+		o, err := e.Reset(seed + int64(i))
+		observations[i] = o
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b.next = seed + int64(len(b.envs))
+
+	return observations, nil
+}
+
+// BatchResult is result of a step of an environment of a batch. Observation of an environment
+// whose game is over is the first of its next game, and FinalObservation the last of the game.
+type BatchResult struct {
+	StepResult
+	FinalObservation []float64
+}
+
+// Step steps every environment of the batch with its action, actions[i] being the action of
+// environment i. Actions are validated before any environment is stepped, so no environment is
+// stepped if one of them is invalid.
+func (b *Batch) Step(actions []Action) ([]BatchResult, error) {
+	if len(actions) != len(b.envs) {
+		return nil, fmt.Errorf("%d actions for a batch of %d", len(actions), len(b.envs))
+	}
+
+	for i, a := range actions {
+		if b.envs[i].game == nil {
+			return nil, fmt.Errorf("environment %d: step before reset", i)
+		}
+
+		if _, err := a.model(); err != nil {
+			return nil, fmt.Errorf("environment %d: %w", i, err)
+		}
+	}
+
+	results := make([]BatchResult, len(b.envs))
+
+	err := b.each(func(i int, e *Env) error {
+		r, err := e.Step(actions[i])
+		if err != nil {
+			return fmt.Errorf("environment %d: %w", i, err)
+		}
+
+		results[i].StepResult = r
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range results {
+		if !r.Done {
+			continue
+		}
+
+		o, err := b.envs[i].Reset(b.next)
+		if err != nil {
+			return nil, err
+		}
+
+		b.next++
+		results[i].FinalObservation = r.Observation
+		results[i].Observation = o
+	}
+
+	return results, nil
+}
+
+// Close stops games of all environments of the batch.
+func (b *Batch) Close() {
+	for _, e := range b.envs {
+		e.Close()
+	}
+}
+
+// each calls f for every environment in a goroutine of its own, and returns the first error.
+func (b *Batch) each(f func(i int, e *Env) error) error {
+	errs := make([]error, len(b.envs))
+
+	var wg sync.WaitGroup
+
+	for i, e := range b.envs {
+		wg.Add(1)
+
+		go func(i int, e *Env) {
+			defer wg.Done()
+
+			errs[i] = f(i, e)
+		}(i, e)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
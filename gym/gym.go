@@ -0,0 +1,268 @@
+// Package gym wraps carrom games as environments for training shot selection agents by
+// reinforcement learning. An agent plays the first player of a game against opponents
+// playing shots drawn from a shot model.
+package gym
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+const (
+	// ActionCount is count of discrete actions, the strike codes, see carrom.StrikeCodeInput.
+	ActionCount = 6
+	// ObservationSize is length of observations: coins on board by colour (red, black, white),
+	// points, foul count, miss streak and presence of four players, the agent first and its
+	// opponents in turn order, and the turn.
+	ObservationSize = 3 + 4*maxPlayers + 1
+
+	maxPlayers = 4
+	agent      = "agent"
+)
+
+// ErrDone is returned for steps of environments whose game is over, until they are reset.
+var ErrDone = errors.New("game is over")
+
+// Config configures environments.
+type Config struct {
+	// Opponents is count of opponents of the agent, 1 to 3.
+	Opponents int
+	// OpponentModel is shot model of opponents. Opponents without one play average shots.
+	OpponentModel *carrom.ShotModel
+	// Noise is chance the shot of the agent is drawn from the average model instead of its
+	// action, as real shots miss.
+	Noise float64
+	// WinReward is added to reward of the step winning the game, and taken off reward of the
+	// step losing it.
+	WinReward float64
+	// TurnCap is turn cap of the games. Games are capped at 300 turns and won by the leader
+	// if it has no cap.
+	TurnCap carrom.TurnCap
+}
+
+var defaultTurnCap = carrom.TurnCap{MaxTurns: 300, Adjudication: carrom.AdjudicateLeader}
+
+func (c Config) validate() error {
+	if c.Opponents < 1 || c.Opponents > maxPlayers-1 {
+		return fmt.Errorf("invalid opponent count %d", c.Opponents)
+	}
+
+	if c.Noise < 0 || c.Noise > 1 {
+		return fmt.Errorf("invalid noise %g", c.Noise)
+	}
+
+	if c.OpponentModel != nil {
+		if err := c.OpponentModel.Validate(); err != nil {
+			return fmt.Errorf("opponent model: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Action is a shot chosen by the agent. The shot is drawn from Weights for continuous policies,
+// or else is the strike of code Discrete. Strikes which aren't possible on the board, eg. red
+// strike once red is pocketed, are missed.
+type Action struct {
+	Discrete int
+	// Weights are relative weights of the strike codes, see carrom.ShotModel.
+	Weights []float64
+}
+
+func (a Action) model() (carrom.ShotModel, error) {
+	var m carrom.ShotModel
+
+	if a.Weights == nil {
+		if a.Discrete < 0 || a.Discrete >= ActionCount {
+			return m, fmt.Errorf("invalid action %d", a.Discrete)
+		}
+
+		m[a.Discrete] = 1
+
+		return m, nil
+	}
+
+	if len(a.Weights) != ActionCount {
+		return m, fmt.Errorf("invalid action of %d weights, want %d", len(a.Weights), ActionCount)
+	}
+
+	copy(m[:], a.Weights)
+
+	return m, m.Validate()
+}
+
+// StepResult is result of a step.
+type StepResult struct {
+	// Observation is observation of the agent after the step, at its next turn.
+	Observation []float64
+	// Reward is gain of the agent in points over the best of its opponents during the step,
+	// plus the win reward if the game is over.
+	Reward float64
+	// Done is set when the game is over. Its outcome is given.
+	Done    bool
+	Outcome carrom.GameOutcome
+}
+
+// Env is an environment playing one game at a time. It is not safe for concurrent use.
+type Env struct {
+	config Config
+	game   *carrom.Game
+	random *rand.Rand
+}
+
+// New returns an environment. It must be reset before its first step.
+func New(c Config) (*Env, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	if c.TurnCap == (carrom.TurnCap{}) {
+		c.TurnCap = defaultTurnCap
+	}
+
+	if c.OpponentModel == nil {
+		m := carrom.ShotModelFromTurns("", nil)
+		c.OpponentModel = &m
+	}
+
+	return &Env{config: c}, nil
+}
+
+// Reset starts a new game seeded by seed and returns the first observation of the agent, who
+// breaks. The same seed and actions play the same game.
+func (e *Env) Reset(seed int64) ([]float64, error) {
+	e.Close()
+
+	e.random = rand.New(rand.NewSource(seed))
+
+	g := carrom.NewGame(fmt.Sprintf("gym-%d", seed))
+	g.SetQuiet(true)
+
+	// observations have no chances, which would only slow steps.
+	if err := g.SetForecast(carrom.Forecast{}); err != nil {
+		return nil, err
+	}
+
+	if err := g.SetTurnCap(e.config.TurnCap); err != nil {
+		return nil, err
+	}
+
+	players := []string{agent}
+	for i := 1; i <= e.config.Opponents; i++ {
+		players = append(players, fmt.Sprintf("opponent%d", i))
+	}
+
+	if err := g.AddPlayers(players); err != nil {
+		return nil, err
+	}
+
+	if _, err := g.Start(); err != nil {
+		return nil, err
+	}
+
+	e.game = g
+
+	return observe(g.Snapshot()), nil
+}
+
+// Step plays the shot of the action for the agent, and then turns of opponents until it is
+// the turn of the agent again or the game is over.
+func (e *Env) Step(a Action) (StepResult, error) {
+	if e.game == nil {
+		return StepResult{}, fmt.Errorf("step before reset")
+	}
+
+	m, err := a.model()
+	if err != nil {
+		return StepResult{}, err
+	}
+
+	s := e.game.Snapshot()
+	if s.State.IsOver() {
+		return StepResult{}, ErrDone
+	}
+
+	before := margin(s)
+
+	if e.random.Float64() < e.config.Noise {
+		m = carrom.ShotModelFromTurns("", nil)
+	}
+
+	for {
+		c := m.Sample(e.random, s.Coins)
+
+		if _, err := e.game.PlayShot(c.Shot()); err != nil {
+			return StepResult{}, err
+		}
+
+		s = e.game.Snapshot()
+		if s.State.IsOver() || s.CurrentPlayer == agent {
+			break
+		}
+
+		m = *e.config.OpponentModel
+	}
+
+	r := StepResult{
+		Observation: observe(s),
+		Reward:      float64(margin(s) - before),
+		Done:        s.State.IsOver(),
+	}
+
+	if r.Done {
+		r.Outcome = e.game.Outcome()
+
+		switch r.Outcome.Winner {
+		case agent:
+			r.Reward += e.config.WinReward
+		case "":
+		default:
+			r.Reward -= e.config.WinReward
+		}
+	}
+
+	return r, nil
+}
+
+// Close stops the game of the environment.
+func (e *Env) Close() {
+	if e.game != nil {
+		e.game.Close()
+	}
+}
+
+// margin returns points of the agent over the best of its opponents.
+func margin(s carrom.GameSnapshot) int {
+	best := 0
+
+	for i, p := range s.Players[1:] {
+		if i == 0 || p.Points > best {
+			best = p.Points
+		}
+	}
+
+	return s.Players[0].Points - best
+}
+
+// observe returns observation of the snapshot, see ObservationSize.
+func observe(s carrom.GameSnapshot) []float64 {
+	o := make([]float64, ObservationSize)
+	o[0], o[1], o[2] = float64(s.Coins.Red), float64(s.Coins.Black), float64(s.Coins.White)
+
+	for i, p := range s.Players {
+		o[3+4*i] = float64(p.Points)
+		o[4+4*i] = float64(p.FoulCount)
+		o[5+4*i] = float64(p.NoPocketCount)
+
+		if !p.Eliminated {
+			o[6+4*i] = 1
+		}
+	}
+
+	o[ObservationSize-1] = float64(s.Turn)
+
+	return o
+}
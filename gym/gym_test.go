@@ -0,0 +1,190 @@
+package gym_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+	"github.com/RenugaParamalingam/carrom/gym"
+)
+
+// blunt opponents never pocket a coin.
+var blunt = carrom.ShotModel{0, 0, 0, 0, 0, 1}
+
+func newEnv(t *testing.T, c gym.Config) *gym.Env {
+	t.Helper()
+
+	e, err := gym.New(c)
+	if err != nil {
+		t.Fatalf("New(%+v)= %v, want= nil", c, err)
+	}
+
+	return e
+}
+
+func TestEnv(t *testing.T) {
+	e := newEnv(t, gym.Config{Opponents: 2, OpponentModel: &blunt, WinReward: 10})
+	defer e.Close()
+
+	if _, err := e.Step(gym.Action{}); err == nil {
+		t.Errorf("Step() before Reset()= nil, want= error")
+	}
+
+	o, err := e.Reset(1)
+	if err != nil {
+		t.Fatalf("Reset()= %v, want= nil", err)
+	}
+
+	want := make([]float64, gym.ObservationSize)
+	want[0], want[1], want[2] = 1, 9, 9
+	want[6], want[10], want[14] = 1, 1, 1
+
+	if !reflect.DeepEqual(o, want) {
+		t.Errorf("Reset()= %v, want= %v", o, want)
+	}
+
+	r, err := e.Step(gym.Action{Discrete: 2})
+	if err != nil {
+		t.Fatalf("Step(red)= %v, want= nil", err)
+	}
+
+	if r.Reward != 3 || r.Done || r.Observation[0] != 0 || r.Observation[3] != 3 || r.Observation[gym.ObservationSize-1] != 3 {
+		t.Errorf("Step(red)= %+v, want= reward 3 with red pocketed at turn 3", r)
+	}
+
+	r, err = e.Step(gym.Action{Weights: []float64{0, 1, 0, 0, 0, 0}})
+	if err != nil {
+		t.Fatalf("Step(multi strike)= %v, want= nil", err)
+	}
+
+	if !r.Done || r.Reward != 12 || r.Outcome.Winner != "agent" {
+		t.Errorf("Step(multi strike)= %+v, want= game won with reward 2 and win reward", r)
+	}
+
+	if _, err := e.Step(gym.Action{}); !errors.Is(err, gym.ErrDone) {
+		t.Errorf("Step() after game is over= %v, want= %v", err, gym.ErrDone)
+	}
+}
+
+func TestEnvIsSeeded(t *testing.T) {
+	play := func() []gym.StepResult {
+		e := newEnv(t, gym.Config{Opponents: 1, Noise: 0.5})
+		defer e.Close()
+
+		if _, err := e.Reset(42); err != nil {
+			t.Fatalf("Reset()= %v, want= nil", err)
+		}
+
+		var results []gym.StepResult
+
+		for i := 0; i < 20; i++ {
+			r, err := e.Step(gym.Action{Discrete: i % gym.ActionCount})
+			if errors.Is(err, gym.ErrDone) {
+				break
+			}
+
+			if err != nil {
+				t.Fatalf("Step()= %v, want= nil", err)
+			}
+
+			results = append(results, r)
+		}
+
+		return results
+	}
+
+	if first, second := play(), play(); !reflect.DeepEqual(first, second) {
+		t.Errorf("games of the same seed and actions differ:\n%+v\n%+v", first, second)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	invalid := carrom.ShotModel{}
+
+	for _, c := range []gym.Config{{}, {Opponents: 4}, {Opponents: 1, Noise: 2}, {Opponents: 1, OpponentModel: &invalid}} {
+		if _, err := gym.New(c); err == nil {
+			t.Errorf("New(%+v)= nil, want= error", c)
+		}
+	}
+
+	e := newEnv(t, gym.Config{Opponents: 1})
+	defer e.Close()
+
+	if _, err := e.Reset(1); err != nil {
+		t.Fatalf("Reset()= %v, want= nil", err)
+	}
+
+	for _, a := range []gym.Action{{Discrete: -1}, {Discrete: gym.ActionCount}, {Weights: []float64{1}}, {Weights: make([]float64, gym.ActionCount)}} {
+		if _, err := e.Step(a); err == nil {
+			t.Errorf("Step(%+v)= nil, want= error", a)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	if _, err := gym.NewBatch(0, gym.Config{Opponents: 1}); err == nil {
+		t.Errorf("NewBatch(0)= nil, want= error")
+	}
+
+	b, err := gym.NewBatch(8, gym.Config{Opponents: 1, OpponentModel: &blunt})
+	if err != nil {
+		t.Fatalf("NewBatch()= %v, want= nil", err)
+	}
+	defer b.Close()
+
+	observations, err := b.Reset(1)
+	if err != nil || len(observations) != b.Len() {
+		t.Fatalf("Reset()= %d observations, err: %v, want= %d", len(observations), err, b.Len())
+	}
+
+	if _, err := b.Step(make([]gym.Action, 3)); err == nil {
+		t.Errorf("Step() of 3 actions= nil, want= error")
+	}
+
+	// no environment is stepped if an action of one of them is invalid.
+	actions := make([]gym.Action, b.Len())
+	for i := range actions {
+		actions[i] = gym.Action{Discrete: 2}
+	}
+
+	actions[3] = gym.Action{Discrete: -1}
+
+	if _, err := b.Step(actions); err == nil {
+		t.Errorf("Step() with an invalid action= nil, want= error")
+	}
+
+	for i := range actions {
+		actions[i] = gym.Action{Discrete: 2}
+	}
+
+	results, err := b.Step(actions)
+	if err != nil {
+		t.Fatalf("Step(red)= %v, want= nil", err)
+	}
+
+	for i, r := range results {
+		if r.Reward != 3 {
+			t.Errorf("result %d of red= %+v, want= reward 3", i, r)
+		}
+	}
+
+	for i := range actions {
+		actions[i] = gym.Action{Discrete: 1}
+	}
+
+	results, err = b.Step(actions)
+	if err != nil {
+		t.Fatalf("Step(multi strike)= %v, want= nil", err)
+	}
+
+	for i, r := range results {
+		if !r.Done || r.Outcome.Winner != "agent" || r.FinalObservation[3] != 5 || r.Observation[1] != 9 {
+			t.Errorf("result %d= %+v, want= game won and reset", i, r)
+		}
+	}
+
+	if _, err := b.Step(actions); err != nil {
+		t.Errorf("Step() after reset by step= %v, want= nil", err)
+	}
+}
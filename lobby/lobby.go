@@ -0,0 +1,637 @@
+// Package lobby hosts many carrom games at once. Players join a game before it
+// starts, and the game is saved to a store as it is played.
+package lobby
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	l "github.com/sirupsen/logrus"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+	"github.com/RenugaParamalingam/carrom/store"
+)
+
+// Status is where a game is in its life.
+type Status string
+
+const (
+	// Open games are waiting for players to join.
+	Open Status = "open"
+	// InProgress games take turns.
+	InProgress Status = "in progress"
+	// Paused games take no turns and their clocks are stopped.
+	Paused Status = "paused"
+	// Finished games have a winner or ended in draw.
+	Finished Status = "finished"
+	// Abandoned games were left before they were over.
+	Abandoned Status = "abandoned"
+)
+
+// ErrNotFound is returned for ids of games which are not in the lobby.
+var ErrNotFound = errors.New("game not found")
+
+// Table describes a game in the lobby.
+type Table struct {
+	ID        string
+	Status    Status
+	Players   []string
+	CreatedAt time.Time
+	StartedAt time.Time
+}
+
+// Lobby keeps games from their creation until they are archived. Turns of a game
+// are played one at a time, while different games are played at the same time.
+type Lobby struct {
+	// SnapshotEvery is number of events after which snapshot of a game is saved.
+	// Snapshots are not saved if it is 0.
+	SnapshotEvery int
+
+	mu     sync.Mutex
+	store  store.Store
+	tables map[string]*table
+	now    func() time.Time
+}
+
+type table struct {
+	mu   sync.Mutex
+	info Table
+	game *carrom.Game
+	// base is seq of the last event before the first turn of game, which is not 0 for games
+	// restored from a snapshot. logged and snapped are seq of the last event and snapshot saved.
+	base    int
+	logged  int
+	snapped int
+	// resulted is set once result of the finished or abandoned game is saved.
+	resulted bool
+}
+
+// snapshot is state of a game saved with store.Snapshot, from which it is restored.
+type snapshot struct {
+	Position carrom.Position
+}
+
+// New returns an empty lobby saving games to s.
+func New(s store.Store) *Lobby {
+	return &Lobby{
+		store:  s,
+		tables: make(map[string]*table, 0),
+		now:    time.Now,
+	}
+}
+
+// Create adds an open game to the lobby and returns its id.
+func (lb *Lobby) Create() (string, error) {
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	lb.tables[id] = &table{info: Table{ID: id, Status: Open, CreatedAt: lb.now()}}
+
+	return id, nil
+}
+
+// Get returns the game with id.
+func (lb *Lobby) Get(id string) (Table, error) {
+	t, err := lb.table(id)
+	if err != nil {
+		return Table{}, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.copyInfo(), nil
+}
+
+// List returns the games in the lobby, oldest first.
+func (lb *Lobby) List() []Table {
+	lb.mu.Lock()
+	tables := make([]*table, 0, len(lb.tables))
+	for _, t := range lb.tables {
+		tables = append(tables, t)
+	}
+	lb.mu.Unlock()
+
+	list := make([]Table, 0, len(tables))
+
+	for _, t := range tables {
+		t.mu.Lock()
+		list = append(list, t.copyInfo())
+		t.mu.Unlock()
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+
+		return list[i].ID < list[j].ID
+	})
+
+	return list
+}
+
+// Join adds player to an open game.
+func (lb *Lobby) Join(id, player string) error {
+	return lb.withTable(id, func(t *table) error {
+		if t.info.Status != Open {
+			return fmt.Errorf("game %s is %s, players can't join", id, t.info.Status)
+		}
+
+		for _, p := range t.info.Players {
+			if p == player {
+				return fmt.Errorf("player %q already joined game %s", player, id)
+			}
+		}
+
+		t.info.Players = append(t.info.Players, player)
+
+		return nil
+	})
+}
+
+// Leave removes player from an open game.
+func (lb *Lobby) Leave(id, player string) error {
+	return lb.withTable(id, func(t *table) error {
+		if t.info.Status != Open {
+			return fmt.Errorf("game %s is %s, players can't leave", id, t.info.Status)
+		}
+
+		for i, p := range t.info.Players {
+			if p == player {
+				t.info.Players = append(t.info.Players[:i], t.info.Players[i+1:]...)
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("player %q is not in game %s", player, id)
+	})
+}
+
+// Start sets up the board of an open game. Players play in the order they joined.
+func (lb *Lobby) Start(id string) error {
+	return lb.withTable(id, func(t *table) error {
+		if t.info.Status != Open {
+			return fmt.Errorf("game %s is %s, it can't be started", id, t.info.Status)
+		}
+
+		game := carrom.NewGame(id)
+		if err := game.AddPlayers(t.info.Players); err != nil {
+			return err
+		}
+
+		t.info.StartedAt = lb.now()
+
+		if lb.store != nil {
+			err := lb.store.CreateGame(store.Game{ID: id, Players: t.info.Players, StartedAt: t.info.StartedAt})
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err := game.Start(); err != nil {
+			return err
+		}
+
+		t.game = game
+		t.info.Status = InProgress
+
+		return nil
+	})
+}
+
+// Restore adds a game saved to the store back to the lobby, eg. after a restart. Game is set up
+// at its latest snapshot and the events after it are played again. Only games in progress are
+// restored, finished and abandoned games are not. Games with turns recorded by
+// the shot clock can't be restored, as time spent by the players is not saved. Fouls declared
+// before the snapshot are not in the result of a restored game, as snapshots don't hold them.
+func (lb *Lobby) Restore(id string) error {
+	if lb.store == nil {
+		return fmt.Errorf("game %s: lobby has no store to restore from", id)
+	}
+
+	if _, err := lb.table(id); err == nil {
+		return fmt.Errorf("game %s is already in the lobby", id)
+	}
+
+	saved, err := lb.store.GetGame(id)
+	if err != nil {
+		return err
+	}
+
+	// finished and abandoned games have a result.
+	if _, err := lb.store.GetResult(id); err == nil {
+		return fmt.Errorf("game %s is over, only games in progress can be restored", id)
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	snap, events, err := store.Recover(lb.store, id)
+	if err != nil {
+		return err
+	}
+
+	game, err := restore(saved, snap)
+	if err != nil {
+		return fmt.Errorf("game %s: %w", id, err)
+	}
+
+	for _, e := range events {
+		if err := replay(game, e); err != nil {
+			game.Close()
+
+			return fmt.Errorf("game %s: event %d: %w", id, e.Seq, err)
+		}
+	}
+
+	if game.IsOver() {
+		game.Close()
+
+		return fmt.Errorf("game %s is over, only games in progress can be restored", id)
+	}
+
+	t := &table{
+		info: Table{
+			ID:        id,
+			Status:    InProgress,
+			Players:   saved.Players,
+			CreatedAt: saved.StartedAt,
+			StartedAt: saved.StartedAt,
+		},
+		game:    game,
+		base:    snap.Seq,
+		logged:  snap.Seq + len(events),
+		snapped: snap.Seq,
+	}
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if _, ok := lb.tables[id]; ok {
+		game.Close()
+
+		return fmt.Errorf("game %s is already in the lobby", id)
+	}
+
+	lb.tables[id] = t
+
+	return nil
+}
+
+// restore starts the saved game at the snapshot, or at the start if it has none.
+func restore(saved store.Game, snap store.Snapshot) (*carrom.Game, error) {
+	game := carrom.NewGame(saved.ID)
+
+	if snap.Seq == 0 {
+		if err := game.AddPlayers(saved.Players); err != nil {
+			return nil, err
+		}
+	} else {
+		var state snapshot
+		if err := json.Unmarshal(snap.State, &state); err != nil {
+			return nil, fmt.Errorf("invalid snapshot: %w", err)
+		}
+
+		if err := game.SetPosition(state.Position); err != nil {
+			return nil, fmt.Errorf("snapshot: %w", err)
+		}
+	}
+
+	if _, err := game.Start(); err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// replay plays the event on the game again.
+func replay(g *carrom.Game, e store.Event) error {
+	if e.Turn == nil {
+		return fmt.Errorf("%s event has no turn", e.Kind)
+	}
+
+	var (
+		res carrom.TurnResult
+		err error
+	)
+
+	switch {
+	case e.Kind == store.FoulEvent:
+		res, err = g.DeclareFoul(e.Turn.PlayerName, e.Turn.Foul)
+	case e.Kind != store.TurnEvent:
+		return fmt.Errorf("%s events can't be played again", e.Kind)
+	case e.Turn.Shot != nil:
+		res, err = g.PlayShot(*e.Turn.Shot)
+	default:
+		res, err = g.PlayTurn(e.Turn.Input)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if res.PlayerName != e.Turn.PlayerName {
+		return fmt.Errorf("turn of %s is played by %s", e.Turn.PlayerName, res.PlayerName)
+	}
+
+	return nil
+}
+
+// PlayTurn plays input on a game in progress. Game is finished once a player wins or
+// it ends in draw.
+func (lb *Lobby) PlayTurn(id string, c carrom.Input) (carrom.TurnResult, error) {
+	return lb.play(id, "turns can't be played", func(g *carrom.Game) (carrom.TurnResult, error) {
+		return g.PlayTurn(c)
+	})
+}
+
+// PlayShot plays shot on a game in progress, see PlayTurn.
+func (lb *Lobby) PlayShot(id string, s carrom.Shot) (carrom.TurnResult, error) {
+	return lb.play(id, "turns can't be played", func(g *carrom.Game) (carrom.TurnResult, error) {
+		return g.PlayShot(s)
+	})
+}
+
+// DeclareFoul declares foul of a player in a game in progress, see carrom.Game.DeclareFoul.
+func (lb *Lobby) DeclareFoul(id, player string, f carrom.FoulType) (carrom.TurnResult, error) {
+	return lb.play(id, "fouls can't be declared", func(g *carrom.Game) (carrom.TurnResult, error) {
+		return g.DeclareFoul(player, f)
+	})
+}
+
+// play calls f on a game in progress and saves what it played.
+func (lb *Lobby) play(id, refusal string, f func(g *carrom.Game) (carrom.TurnResult, error)) (carrom.TurnResult, error) {
+	var res carrom.TurnResult
+
+	err := lb.withTable(id, func(t *table) error {
+		if t.info.Status != InProgress {
+			return fmt.Errorf("game %s is %s, %s", id, t.info.Status, refusal)
+		}
+
+		var err error
+
+		// a game can be over without a valid turn, when a clock runs out.
+		res, err = f(t.game)
+		if t.game.IsOver() {
+			t.info.Status = Finished
+		}
+
+		if saveErr := lb.save(t); saveErr != nil {
+			return saveErr
+		}
+
+		return err
+	})
+
+	return res, err
+}
+
+// Pause stops a game in progress and its clocks until Resume.
+func (lb *Lobby) Pause(id string) error {
+	return lb.withTable(id, func(t *table) error {
+		if t.info.Status != InProgress {
+			return fmt.Errorf("game %s is %s, it can't be paused", id, t.info.Status)
+		}
+
+		// games without clock have nothing to pause but turns.
+		_ = t.game.PauseClock()
+		t.info.Status = Paused
+
+		return nil
+	})
+}
+
+// Resume continues a paused game.
+func (lb *Lobby) Resume(id string) error {
+	return lb.withTable(id, func(t *table) error {
+		if t.info.Status != Paused {
+			return fmt.Errorf("game %s is %s, it can't be resumed", id, t.info.Status)
+		}
+
+		_ = t.game.ResumeClock()
+		t.info.Status = InProgress
+
+		return nil
+	})
+}
+
+// Abandon ends a game which is not over. Its board stops taking input and its clocks stop.
+func (lb *Lobby) Abandon(id string) error {
+	return lb.withTable(id, func(t *table) error {
+		switch t.info.Status {
+		case Finished, Abandoned:
+			return fmt.Errorf("game %s is already %s", id, t.info.Status)
+		case Open:
+			t.info.Status = Abandoned
+
+			return nil
+		}
+
+		// a clock can end the game between turns.
+		if err := t.game.Abandon(); err != nil {
+			if !t.game.IsOver() {
+				return err
+			}
+
+			t.info.Status = Finished
+
+			return lb.save(t)
+		}
+
+		t.info.Status = Abandoned
+
+		return lb.save(t)
+	})
+}
+
+// Archive removes a finished or abandoned game from the lobby once everything
+// played on it is saved.
+func (lb *Lobby) Archive(id string) error {
+	err := lb.withTable(id, func(t *table) error {
+		if t.info.Status != Finished && t.info.Status != Abandoned {
+			return fmt.Errorf("game %s is %s, only finished and abandoned games can be archived", id, t.info.Status)
+		}
+
+		if err := lb.save(t); err != nil {
+			return err
+		}
+
+		if t.game != nil {
+			t.game.Close()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lb.mu.Lock()
+	delete(lb.tables, id)
+	lb.mu.Unlock()
+
+	l.WithField("game", id).Infoln("game archived")
+
+	return nil
+}
+
+// Close abandons every game which is not over.
+func (lb *Lobby) Close() {
+	for _, t := range lb.List() {
+		if t.Status != Finished && t.Status != Abandoned {
+			if err := lb.Abandon(t.ID); err != nil {
+				l.WithError(err).WithField("game", t.ID).Errorln("failed to abandon game")
+			}
+		}
+	}
+}
+
+func (lb *Lobby) table(id string) (*table, error) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	t, ok := lb.tables[id]
+	if !ok {
+		return nil, fmt.Errorf("game %s: %w", id, ErrNotFound)
+	}
+
+	return t, nil
+}
+
+// withTable calls f holding the lock of the game, so operations on a game never overlap.
+func (lb *Lobby) withTable(id string, f func(t *table) error) error {
+	t, err := lb.table(id)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return f(t)
+}
+
+// save appends turns not saved yet to the event log of the game, including turns recorded
+// by the shot clock, and saves snapshot and result when due.
+func (lb *Lobby) save(t *table) error {
+	if lb.store == nil || t.game == nil {
+		return nil
+	}
+
+	turns := t.game.Turns()
+	events := make([]store.Event, 0, len(turns)-(t.logged-t.base))
+
+	for i := t.logged - t.base; i < len(turns); i++ {
+		turn := turns[i]
+		kind := store.TurnEvent
+
+		switch {
+		case turn.Timeout:
+			kind = store.TimeoutEvent
+		case turn.Foul != 0:
+			kind = store.FoulEvent
+		}
+
+		events = append(events, store.Event{Seq: t.base + i + 1, Time: lb.now(), Kind: kind, Turn: &turn})
+	}
+
+	if len(events) > 0 {
+		if err := lb.store.AppendEvents(t.info.ID, events...); err != nil {
+			return err
+		}
+
+		t.logged = t.base + len(turns)
+	}
+
+	if err := lb.saveSnapshot(t); err != nil {
+		return err
+	}
+
+	// abandoned games have a result, so they are known to be over.
+	if (t.info.Status != Finished && t.info.Status != Abandoned) || t.resulted {
+		return nil
+	}
+
+	outcome := t.game.Outcome()
+	result := store.Result{
+		GameID:     t.info.ID,
+		Players:    t.info.Players,
+		Winner:     outcome.Winner,
+		Points:     make(map[string]int, len(t.info.Players)),
+		Outcome:    outcome.Kind.String(),
+		Reason:     outcome.Reason,
+		Margin:     outcome.Margin,
+		Fouls:      make(map[string][]carrom.Foul, len(t.info.Players)),
+		FinishedAt: lb.now(),
+	}
+
+	for _, s := range outcome.Standings {
+		result.Points[s.PlayerName] = s.Points
+
+		if len(s.FoulHistory) > 0 {
+			result.Fouls[s.PlayerName] = s.FoulHistory
+		}
+	}
+
+	if err := lb.store.SaveResult(result); err != nil {
+		return err
+	}
+
+	t.resulted = true
+
+	return nil
+}
+
+// saveSnapshot saves position of the game when a snapshot is due. Snapshots are not saved while
+// the game can't be held by a position, see carrom.Game.Position.
+func (lb *Lobby) saveSnapshot(t *table) error {
+	if lb.SnapshotEvery == 0 || t.logged-t.snapped < lb.SnapshotEvery {
+		return nil
+	}
+
+	pos, ok := t.game.Position()
+	if !ok {
+		return nil
+	}
+
+	state, err := json.Marshal(snapshot{Position: pos})
+	if err != nil {
+		return err
+	}
+
+	if err := lb.store.SaveSnapshot(t.info.ID, store.Snapshot{Seq: t.logged, Time: lb.now(), State: state}); err != nil {
+		return err
+	}
+
+	t.snapped = t.logged
+
+	return nil
+}
+
+func (t *table) copyInfo() Table {
+	info := t.info
+	info.Players = append([]string(nil), t.info.Players...)
+
+	return info
+}
+
+func newID() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
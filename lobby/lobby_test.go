@@ -0,0 +1,391 @@
+package lobby_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+	"github.com/RenugaParamalingam/carrom/lobby"
+	"github.com/RenugaParamalingam/carrom/store"
+)
+
+// firstPlayerWins makes the first player win by 5 points.
+var firstPlayerWins = []carrom.Input{
+	{StrikeCode: 2},
+	{StrikeCode: 5},
+	{StrikeCode: 1, CoinsPocketedCount: carrom.CoinsPocketedCount{Black: 2}},
+}
+
+func openGame(t *testing.T, lb *lobby.Lobby, players ...string) string {
+	t.Helper()
+
+	id, err := lb.Create()
+	if err != nil {
+		t.Fatalf("Create()= %v, want= nil", err)
+	}
+
+	for _, p := range players {
+		if err := lb.Join(id, p); err != nil {
+			t.Fatalf("Join(%s)= %v, want= nil", p, err)
+		}
+	}
+
+	return id
+}
+
+func TestJoinAndLeave(t *testing.T) {
+	lb := lobby.New(nil)
+	id := openGame(t, lb, "p1", "p2", "p3")
+
+	if err := lb.Join(id, "p2"); err == nil {
+		t.Errorf("Join(p2) again= nil, want= error")
+	}
+
+	if err := lb.Leave(id, "p2"); err != nil {
+		t.Errorf("Leave(p2)= %v, want= nil", err)
+	}
+
+	if err := lb.Leave(id, "p4"); err == nil {
+		t.Errorf("Leave(p4)= nil, want= error")
+	}
+
+	if err := lb.Start(id); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	if err := lb.Join(id, "p4"); err == nil {
+		t.Errorf("Join() after start= nil, want= error")
+	}
+
+	table, _ := lb.Get(id)
+	if table.Status != lobby.InProgress || fmt.Sprint(table.Players) != "[p1 p3]" {
+		t.Errorf("Get()= %+v, want= in progress game of [p1 p3]", table)
+	}
+
+	if _, err := lb.Get("unknown"); !errors.Is(err, lobby.ErrNotFound) {
+		t.Errorf("Get(unknown)= %v, want= %v", err, lobby.ErrNotFound)
+	}
+
+	if err := lb.Start(openGame(t, lb, "p1")); err == nil {
+		t.Errorf("Start() with one player= nil, want= error")
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	s := store.NewMemory()
+	lb := lobby.New(s)
+	lb.SnapshotEvery = 2
+
+	id := openGame(t, lb, "p1", "p2")
+
+	if _, err := lb.PlayTurn(id, firstPlayerWins[0]); err == nil {
+		t.Errorf("PlayTurn() before start= nil, want= error")
+	}
+
+	if err := lb.Start(id); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	if err := lb.Pause(id); err != nil {
+		t.Fatalf("Pause()= %v, want= nil", err)
+	}
+
+	if _, err := lb.PlayTurn(id, firstPlayerWins[0]); err == nil {
+		t.Errorf("PlayTurn() of paused game= nil, want= error")
+	}
+
+	if err := lb.Archive(id); err == nil {
+		t.Errorf("Archive() of paused game= nil, want= error")
+	}
+
+	if err := lb.Resume(id); err != nil {
+		t.Fatalf("Resume()= %v, want= nil", err)
+	}
+
+	for _, c := range firstPlayerWins {
+		if _, err := lb.PlayTurn(id, c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	if table, _ := lb.Get(id); table.Status != lobby.Finished {
+		t.Errorf("Get()= %s, want= %s", table.Status, lobby.Finished)
+	}
+
+	if err := lb.Archive(id); err != nil {
+		t.Fatalf("Archive()= %v, want= nil", err)
+	}
+
+	if len(lb.List()) != 0 {
+		t.Errorf("List() after archive= %+v, want= none", lb.List())
+	}
+
+	events, _ := s.Events(id, 0)
+	result, err := s.GetResult(id)
+
+	if len(events) != 3 || err != nil || result.Winner != "p1" || result.Points["p1"] != 5 {
+		t.Errorf("saved game= %d events, result: %+v, err: %v, want= 3 events, p1 winning with 5", len(events), result, err)
+	}
+
+	if result.Outcome != "won" || result.Reason != carrom.ReasonLeadReached || result.Margin != 5 {
+		t.Errorf("saved result= %+v, want= won by lead of 5", result)
+	}
+
+	if snapshot, ok, _ := s.LatestSnapshot(id); !ok || snapshot.Seq != 2 {
+		t.Errorf("LatestSnapshot()= %+v, %t, want= snapshot after event 2", snapshot, ok)
+	}
+
+	var state struct{ Position carrom.Position }
+
+	snapshot, _, _ := s.LatestSnapshot(id)
+	if err := json.Unmarshal(snapshot.State, &state); err != nil || state.Position.Players[0].Pocketed != (carrom.Coins{Red: 1}) {
+		t.Errorf("snapshot state= %s, err: %v, want= red held by p1", snapshot.State, err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	s, err := store.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFS()= %v, want= nil", err)
+	}
+
+	lb := lobby.New(s)
+	lb.SnapshotEvery = 2
+	id := openGame(t, lb, "p1", "p2")
+
+	if err := lb.Start(id); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	// the snapshot is taken after the foul and red, and the miss of p2 is played again.
+	if _, err := lb.DeclareFoul(id, "p2", carrom.FoulTouchingCoin); err != nil {
+		t.Fatalf("DeclareFoul()= %v, want= nil", err)
+	}
+
+	for _, c := range firstPlayerWins[:2] {
+		if _, err := lb.PlayTurn(id, c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	if err := lb.Restore(id); err == nil {
+		t.Errorf("Restore() of game in the lobby= nil, want= error")
+	}
+
+	restarted := lobby.New(s)
+	restarted.SnapshotEvery = 2
+
+	if err := restarted.Restore("unknown"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Restore(unknown)= %v, want= %v", err, store.ErrNotFound)
+	}
+
+	if err := restarted.Restore(id); err != nil {
+		t.Fatalf("Restore()= %v, want= nil", err)
+	}
+
+	if table, _ := restarted.Get(id); table.Status != lobby.InProgress || fmt.Sprint(table.Players) != "[p1 p2]" {
+		t.Errorf("Get() after restore= %+v, want= in progress game of [p1 p2]", table)
+	}
+
+	if _, err := restarted.PlayTurn(id, firstPlayerWins[2]); err != nil {
+		t.Fatalf("PlayTurn()= %v, want= nil", err)
+	}
+
+	events, _ := s.Events(id, 2)
+	result, err := s.GetResult(id)
+
+	if len(events) != 2 || events[1].Seq != 4 || events[1].Turn.Turn != 3 {
+		t.Errorf("Events()= %+v, want= events 3 and 4, event 4 being turn 3", events)
+	}
+
+	if err != nil || result.Winner != "p1" || result.Points["p1"] != 5 || result.Points["p2"] != -1 {
+		t.Errorf("saved result= %+v, err: %v, want= p1 winning with 5, p2 on -1", result, err)
+	}
+
+	if err := lobby.New(s).Restore(id); err == nil {
+		t.Errorf("Restore() of finished game= nil, want= error")
+	}
+}
+
+func TestRestoreReRackedBoard(t *testing.T) {
+	if err := carrom.SetDrawBreak(carrom.DrawBreak{Procedure: carrom.SuddenDeathReRack}); err != nil {
+		t.Fatalf("SetDrawBreak()= %v, want= nil", err)
+	}
+	defer carrom.SetDrawBreak(carrom.DrawBreak{})
+
+	s := store.NewMemory()
+	lb := lobby.New(s)
+	lb.SnapshotEvery = 1
+	id := openGame(t, lb, "p1", "p2")
+
+	if err := lb.Start(id); err != nil {
+		t.Fatalf("Start()= %v, want= nil", err)
+	}
+
+	// the board is cleared without a winner and re-racked, and p1 misses in sudden death.
+	for _, c := range []carrom.Input{
+		{StrikeCode: 1, CoinsPocketedCount: carrom.CoinsPocketedCount{Black: 9, IsRedPocketed: true}},
+		{StrikeCode: 1, CoinsPocketedCount: carrom.CoinsPocketedCount{White: 9}},
+		{StrikeCode: 5},
+	} {
+		if _, err := lb.PlayTurn(id, c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	if snapshot, _, _ := s.LatestSnapshot(id); snapshot.Seq != 1 {
+		t.Errorf("LatestSnapshot()= %+v, want= snapshot after event 1, before the re-rack", snapshot)
+	}
+
+	restarted := lobby.New(s)
+	if err := restarted.Restore(id); err != nil {
+		t.Fatalf("Restore()= %v, want= nil", err)
+	}
+
+	// p2 misses too, and the round of sudden death ends without a leader.
+	if _, err := restarted.PlayTurn(id, carrom.Input{StrikeCode: 5}); err != nil {
+		t.Fatalf("PlayTurn()= %v, want= nil", err)
+	}
+
+	table, _ := restarted.Get(id)
+	result, err := s.GetResult(id)
+
+	if table.Status != lobby.Finished || err != nil || result.Outcome != "drawn" || result.Reason != carrom.ReasonSuddenDeath {
+		t.Errorf("restored game= %s, result: %+v, err: %v, want= drawn in sudden death", table.Status, result, err)
+	}
+}
+
+func TestAbandon(t *testing.T) {
+	s := store.NewMemory()
+	lb := lobby.New(s)
+	id := openGame(t, lb, "p1", "p2")
+
+	lb.Start(id)
+
+	if _, err := lb.PlayTurn(id, firstPlayerWins[0]); err != nil {
+		t.Fatalf("PlayTurn()= %v, want= nil", err)
+	}
+
+	if err := lb.Abandon(id); err != nil {
+		t.Fatalf("Abandon()= %v, want= nil", err)
+	}
+
+	if _, err := lb.PlayTurn(id, firstPlayerWins[0]); err == nil {
+		t.Errorf("PlayTurn() of abandoned game= nil, want= error")
+	}
+
+	if err := lb.Abandon(id); err == nil {
+		t.Errorf("Abandon() again= nil, want= error")
+	}
+
+	if err := lb.Archive(id); err != nil {
+		t.Errorf("Archive()= %v, want= nil", err)
+	}
+
+	if result, err := s.GetResult(id); err != nil || result.Outcome != "abandoned" || result.Winner != "" {
+		t.Errorf("saved result= %+v, err: %v, want= abandoned without winner", result, err)
+	}
+
+	if err := lobby.New(s).Restore(id); err == nil {
+		t.Errorf("Restore() of abandoned game= nil, want= error")
+	}
+}
+
+func TestConcurrentGames(t *testing.T) {
+	s := store.NewMemory()
+	lb := lobby.New(s)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		id := openGame(t, lb, "p1", "p2")
+		lb.Start(id)
+
+		wg.Add(1)
+
+		go func(id string) {
+			defer wg.Done()
+
+			for _, c := range firstPlayerWins {
+				if _, err := lb.PlayTurn(id, c); err != nil {
+					t.Errorf("PlayTurn(%s, %v)= %v, want= nil", id, c, err)
+				}
+			}
+		}(id)
+	}
+
+	wg.Wait()
+
+	for _, table := range lb.List() {
+		if table.Status != lobby.Finished {
+			t.Errorf("game %s= %s, want= %s", table.ID, table.Status, lobby.Finished)
+		}
+	}
+
+	if results, _ := s.ListResults(store.Query{Player: "p1"}); len(results) != 20 {
+		t.Errorf("ListResults()= %d results, want= 20", len(results))
+	}
+}
+
+func TestDeclareFoul(t *testing.T) {
+	s := store.NewMemory()
+	lb := lobby.New(s)
+	id := openGame(t, lb, "p1", "p2")
+
+	if _, err := lb.DeclareFoul(id, "p2", carrom.FoulTouchingCoin); err == nil {
+		t.Errorf("DeclareFoul() before start= nil, want= error")
+	}
+
+	lb.Start(id)
+
+	if _, err := lb.DeclareFoul(id, "p2", carrom.FoulTouchingCoin); err != nil {
+		t.Fatalf("DeclareFoul()= %v, want= nil", err)
+	}
+
+	for _, c := range firstPlayerWins {
+		if _, err := lb.PlayTurn(id, c); err != nil {
+			t.Fatalf("PlayTurn(%v)= %v, want= nil", c, err)
+		}
+	}
+
+	if err := lb.Archive(id); err != nil {
+		t.Fatalf("Archive()= %v, want= nil", err)
+	}
+
+	events, _ := s.Events(id, 0)
+	result, _ := s.GetResult(id)
+
+	if len(events) != 4 || events[0].Kind != store.FoulEvent {
+		t.Errorf("Events()= %+v, want= foul event and 3 turns", events)
+	}
+
+	if fouls := result.Fouls["p2"]; result.Winner != "p1" || len(fouls) != 1 || fouls[0].Type != carrom.FoulTouchingCoin {
+		t.Errorf("saved result= %+v, want= foul of p2 in result", result)
+	}
+}
+
+func TestPlayShot(t *testing.T) {
+	s := store.NewMemory()
+	lb := lobby.New(s)
+	id := openGame(t, lb, "p1", "p2")
+
+	lb.Start(id)
+
+	shot := carrom.Shot{
+		Pocketed:    carrom.CoinsPocketedCount{Black: 1},
+		Pockets:     []carrom.CoinPocket{{Coin: "black", Pocket: carrom.PocketNorthWest}},
+		StrikerSide: carrom.SideSouth,
+	}
+
+	if _, err := lb.PlayShot(id, shot); err != nil {
+		t.Fatalf("PlayShot()= %v, want= nil", err)
+	}
+
+	events, _ := s.Events(id, 0)
+	if len(events) != 1 || events[0].Turn.Shot == nil || events[0].Turn.Shot.Pockets[0] != shot.Pockets[0] {
+		t.Errorf("Events()= %+v, want= the shot with its pocket", events)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"math/rand"
+	"os"
 	"time"
 
 	l "github.com/sirupsen/logrus"
@@ -10,12 +11,37 @@ import (
 	"github.com/RenugaParamalingam/carrom/carrom"
 )
 
+// commands are run by their name given as the first argument. Random games are played
+// without one.
+var commands = map[string]func(args []string) error{
+	"arena":    arenaCommand,
+	"position": positionCommand,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		command, ok := commands[os.Args[1]]
+		if !ok {
+			l.Fatalf("unknown command %q", os.Args[1])
+		}
+
+		if err := command(os.Args[2:]); err != nil {
+			l.WithError(err).Fatalf("%s failed", os.Args[1])
+		}
+
+		return
+	}
+
 	names := []string{"p1", "p2", "p3", "p4"}
 	resetGame := true
 
 	rand.Seed(int64(time.Now().Nanosecond()))
 
+	// random input rarely pockets coins, so the game is decided by the leader after 100 turns.
+	if err := carrom.SetTurnCap(carrom.TurnCap{MaxTurns: 100, Adjudication: carrom.AdjudicateLeader}); err != nil {
+		l.WithError(err).Fatalln("invalid turn cap")
+	}
+
 	for resetGame {
 		start := rand.Intn(4)
 		end := rand.Intn(4)
@@ -50,15 +76,22 @@ func startGame(playerNames []string) error {
 	rand.Seed(int64(time.Now().Nanosecond()))
 
 	for !shouldEndGame {
-		strikeInput <- carrom.Input{
-			StrikeCode: rand.Intn(6),
-			CoinsPocketedCount: carrom.CoinsPocketedCount{
-				Black:         rand.Intn(10),
-				White:         rand.Intn(10),
+		shot := carrom.Shot{
+			Pocketed: carrom.CoinsPocketedCount{
+				Black:         rand.Intn(3),
+				White:         rand.Intn(3),
 				IsRedPocketed: redCoinRandomness[rand.Intn(2)],
 			},
+			StrikerPocketed: rand.Intn(10) == 0,
 		}
 
+		input, err := shot.Classify()
+		if err != nil {
+			continue
+		}
+
+		strikeInput <- input
+
 		shouldEndGame = carrom.IsGameOver()
 	}
 
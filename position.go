@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+)
+
+// positionCommand sets up a game at the position read from a JSON file, plays the turns read
+// from stdin one per line in input notation, eg. "1 b=2", and prints snapshot of the game.
+// Lines starting with # are skipped.
+func positionCommand(args []string) error {
+	flags := flag.NewFlagSet("position", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: carrom position position.json < turns")
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return fmt.Errorf("position file is not given")
+	}
+
+	data, err := ioutil.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var pos carrom.Position
+	if err := json.Unmarshal(data, &pos); err != nil {
+		return fmt.Errorf("invalid position file: %w", err)
+	}
+
+	g := carrom.NewGame("position")
+	if err := g.SetPosition(pos); err != nil {
+		return err
+	}
+
+	if _, err := g.Start(); err != nil {
+		return err
+	}
+
+	defer g.Close()
+
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		c, err := carrom.ParseInput(line)
+		if err != nil {
+			return err
+		}
+
+		if _, err := g.PlayTurn(c); err != nil {
+			return fmt.Errorf("turn %q: %w", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+
+	return out.Encode(g.Snapshot())
+}
@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Hand is hand a player strikes with.
+type Hand string
+
+const (
+	// UnknownHand is used when player hasn't told his dominant hand.
+	UnknownHand  Hand = ""
+	LeftHand     Hand = "left"
+	RightHand    Hand = "right"
+	Ambidextrous Hand = "ambidextrous"
+)
+
+const maxNameLength = 32
+
+var (
+	// ErrNotFound is returned when no profile matches the id or name looked up.
+	ErrNotFound = errors.New("player not found")
+	// ErrDuplicateName is returned when display name is already taken by another player.
+	ErrDuplicateName = errors.New("player name already taken")
+)
+
+// Profile is a player known across games. ID never changes once player is registered,
+// so games, stats and ratings refer to players by it.
+type Profile struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"displayName"`
+	Nickname    string    `json:"nickname,omitempty"`
+	Club        string    `json:"club,omitempty"`
+	Hand        Hand      `json:"hand,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// NormalizeName trims name and collapses runs of white space in it to a single space.
+// An error is returned if the result is empty, longer than 32 characters or has
+// characters other than letters, digits, spaces and . ' - _
+func NormalizeName(name string) (string, error) {
+	normalized := strings.Join(strings.Fields(name), " ")
+
+	if normalized == "" {
+		return "", fmt.Errorf("empty player name")
+	}
+
+	if len([]rune(normalized)) > maxNameLength {
+		return "", fmt.Errorf("player name %q is longer than %d characters", normalized, maxNameLength)
+	}
+
+	for _, r := range normalized {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" .'-_", r) {
+			return "", fmt.Errorf("player name %q has invalid character %q", normalized, r)
+		}
+	}
+
+	return normalized, nil
+}
+
+// nameKey is the form two names are compared in, so names differing only in case are same.
+func nameKey(normalized string) string {
+	return strings.ToLower(normalized)
+}
+
+func (p *Profile) normalize() error {
+	name, err := NormalizeName(p.DisplayName)
+	if err != nil {
+		return err
+	}
+
+	p.DisplayName = name
+	p.Nickname = strings.Join(strings.Fields(p.Nickname), " ")
+	p.Club = strings.Join(strings.Fields(p.Club), " ")
+
+	switch p.Hand {
+	case UnknownHand, LeftHand, RightHand, Ambidextrous:
+	default:
+		return fmt.Errorf("invalid dominant hand %q", p.Hand)
+	}
+
+	return nil
+}
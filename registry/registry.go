@@ -0,0 +1,175 @@
+// Package registry keeps players across games, so the same person is known by the same
+// profile in every game, statistic and rating.
+package registry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Registry registers and looks up player profiles. Display names are unique
+// regardless of their case.
+type Registry struct {
+	mu     sync.Mutex
+	store  Store
+	byName map[string]string
+	now    func() time.Time
+}
+
+// New returns registry of the profiles in store.
+func New(store Store) (*Registry, error) {
+	profiles, err := store.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Registry{
+		store:  store,
+		byName: make(map[string]string, len(profiles)),
+		now:    time.Now,
+	}
+
+	for _, p := range profiles {
+		r.byName[nameKey(p.DisplayName)] = p.ID
+	}
+
+	return r, nil
+}
+
+// Open returns registry saving profiles to the file at path.
+func Open(path string) (*Registry, error) {
+	return New(NewFileStore(path))
+}
+
+// Register adds a new player. ID and CreatedAt of p are ignored and set by registry.
+func (r *Registry) Register(p Profile) (Profile, error) {
+	if err := p.normalize(); err != nil {
+		return Profile{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byName[nameKey(p.DisplayName)]; ok {
+		return Profile{}, fmt.Errorf("%q: %w", p.DisplayName, ErrDuplicateName)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Profile{}, err
+	}
+
+	p.ID = id
+	p.CreatedAt = r.now().UTC()
+
+	if err := r.store.PutProfile(p); err != nil {
+		return Profile{}, err
+	}
+
+	r.byName[nameKey(p.DisplayName)] = p.ID
+
+	return p, nil
+}
+
+// Update changes details of a registered player. ID and CreatedAt can't be changed.
+func (r *Registry) Update(p Profile) (Profile, error) {
+	if err := p.normalize(); err != nil {
+		return Profile{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, err := r.store.GetProfile(p.ID)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	if id, ok := r.byName[nameKey(p.DisplayName)]; ok && id != p.ID {
+		return Profile{}, fmt.Errorf("%q: %w", p.DisplayName, ErrDuplicateName)
+	}
+
+	p.CreatedAt = old.CreatedAt
+
+	if err := r.store.PutProfile(p); err != nil {
+		return Profile{}, err
+	}
+
+	delete(r.byName, nameKey(old.DisplayName))
+	r.byName[nameKey(p.DisplayName)] = p.ID
+
+	return p, nil
+}
+
+// Get returns profile of player with id.
+func (r *Registry) Get(id string) (Profile, error) {
+	return r.store.GetProfile(id)
+}
+
+// FindByName returns profile of player with display name, compared after normalizing
+// and regardless of case.
+func (r *Registry) FindByName(name string) (Profile, error) {
+	normalized, err := NormalizeName(name)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	r.mu.Lock()
+	id, ok := r.byName[nameKey(normalized)]
+	r.mu.Unlock()
+
+	if !ok {
+		return Profile{}, fmt.Errorf("%q: %w", normalized, ErrNotFound)
+	}
+
+	return r.store.GetProfile(id)
+}
+
+// List returns all registered players in the order they registered.
+func (r *Registry) List() ([]Profile, error) {
+	return r.store.ListProfiles()
+}
+
+// Resolve looks up players by id or display name, and returns their profiles
+// in the same order. Display names of the result can be passed to carrom.AddPlayersToGame.
+func (r *Registry) Resolve(idsOrNames []string) ([]Profile, error) {
+	profiles := make([]Profile, 0, len(idsOrNames))
+
+	for _, s := range idsOrNames {
+		p, err := r.store.GetProfile(s)
+		if errors.Is(err, ErrNotFound) {
+			p, err = r.FindByName(s)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		profiles = append(profiles, p)
+	}
+
+	return profiles, nil
+}
+
+// DisplayNames returns display names of profiles.
+func DisplayNames(profiles []Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		names = append(names, p.DisplayName)
+	}
+
+	return names
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,83 @@
+package registry_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/RenugaParamalingam/carrom/registry"
+)
+
+func TestNormalizeName(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expectedName  string
+		expectedValid bool
+	}{
+		{"  Renuga   P ", "Renuga P", true},
+		{"O'Neil-Jr_2.0", "O'Neil-Jr_2.0", true},
+		{"   ", "", false},
+		{"p1;drop", "", false},
+		{"abcdefghijklmnopqrstuvwxyzabcdefg", "", false},
+	}
+
+	for _, tc := range testCases {
+		actual, err := registry.NormalizeName(tc.name)
+
+		if actual != tc.expectedName || (err == nil) != tc.expectedValid {
+			t.Errorf("NormalizeName(%q)= %q, err: %v, want= %q, valid: %t", tc.name, actual, err, tc.expectedName, tc.expectedValid)
+		}
+	}
+}
+
+func TestRegistryPersistsProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "players.json")
+
+	r, err := registry.Open(path)
+	if err != nil {
+		t.Fatalf("Open()= %v, want= nil", err)
+	}
+
+	p1, err := r.Register(registry.Profile{DisplayName: " Renuga ", Club: "Chennai", Hand: registry.RightHand})
+	if err != nil || p1.ID == "" || p1.DisplayName != "Renuga" || p1.CreatedAt.IsZero() {
+		t.Fatalf("Register()= %+v, err: %v, want= profile with id", p1, err)
+	}
+
+	if _, err := r.Register(registry.Profile{DisplayName: "RENUGA"}); !errors.Is(err, registry.ErrDuplicateName) {
+		t.Errorf("Register() with taken name= %v, want= %v", err, registry.ErrDuplicateName)
+	}
+
+	if _, err := r.Register(registry.Profile{DisplayName: "p2", Hand: "both"}); err == nil {
+		t.Errorf("Register() with invalid hand= nil, want= error")
+	}
+
+	p2, _ := r.Register(registry.Profile{DisplayName: "p2"})
+
+	p2.Nickname = "striker"
+	if _, err := r.Update(p2); err != nil {
+		t.Fatalf("Update()= %v, want= nil", err)
+	}
+
+	p2.DisplayName = "renuga"
+	if _, err := r.Update(p2); !errors.Is(err, registry.ErrDuplicateName) {
+		t.Errorf("Update() to taken name= %v, want= %v", err, registry.ErrDuplicateName)
+	}
+
+	reopened, err := registry.Open(path)
+	if err != nil {
+		t.Fatalf("Open()= %v, want= nil", err)
+	}
+
+	players, err := reopened.Resolve([]string{"renuga", p2.ID})
+	if err != nil {
+		t.Fatalf("Resolve()= %v, want= nil", err)
+	}
+
+	if players[0] != p1 || players[1].Nickname != "striker" || players[1].DisplayName != "p2" {
+		t.Errorf("Resolve()= %+v, want= [%+v p2 with nickname]", players, p1)
+	}
+
+	if _, err := reopened.FindByName("p3"); !errors.Is(err, registry.ErrNotFound) {
+		t.Errorf("FindByName(p3)= %v, want= %v", err, registry.ErrNotFound)
+	}
+}
@@ -0,0 +1,150 @@
+package registry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Store keeps profiles of registered players.
+type Store interface {
+	// PutProfile adds profile or replaces the one with the same ID.
+	PutProfile(p Profile) error
+	// GetProfile returns ErrNotFound if there is no profile with id.
+	GetProfile(id string) (Profile, error)
+	ListProfiles() ([]Profile, error)
+}
+
+// FileStore keeps profiles as JSON in a single file. File is replaced as a whole
+// on every change, so it is never left half written.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore returns a store saving to path. The file is created on first change.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// PutProfile adds profile or replaces the one with the same ID.
+func (s *FileStore) PutProfile(p Profile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	profiles[p.ID] = p
+
+	return s.write(profiles)
+}
+
+// GetProfile returns ErrNotFound if there is no profile with id.
+func (s *FileStore) GetProfile(id string) (Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles, err := s.read()
+	if err != nil {
+		return Profile{}, err
+	}
+
+	p, ok := profiles[id]
+	if !ok {
+		return Profile{}, ErrNotFound
+	}
+
+	return p, nil
+}
+
+// ListProfiles returns profiles in the order they were created.
+func (s *FileStore) ListProfiles() ([]Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedProfiles(profiles), nil
+}
+
+func (s *FileStore) read() (map[string]Profile, error) {
+	profiles := make(map[string]Profile, 0)
+
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Profile
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	for _, p := range list {
+		profiles[p.ID] = p
+	}
+
+	return profiles, nil
+}
+
+func (s *FileStore) write(profiles map[string]Profile) error {
+	data, err := json.MarshalIndent(sortedProfiles(profiles), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func sortedProfiles(profiles map[string]Profile) []Profile {
+	list := make([]Profile, 0, len(profiles))
+	for _, p := range profiles {
+		list = append(list, p)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+
+		return list[i].ID < list[j].ID
+	})
+
+	return list
+}
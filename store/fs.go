@@ -0,0 +1,531 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/RenugaParamalingam/carrom/registry"
+)
+
+var validGameID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// FS is a Store keeping its data in a directory,
+//
+//	players.json              profiles of the players
+//	games/<id>/game.json      game as created
+//	games/<id>/journal.jsonl  event log after the latest snapshot, one event a line
+//	games/<id>/snapshot.json  latest snapshot
+//	games/<id>/result.json    result once game is finished
+//
+// Events are appended to the journal and synced to disk before AppendEvents returns.
+// A line left half written by a crash is dropped when the journal is next read. Once a
+// snapshot is saved, the journal is compacted to the events after it, so Events returns
+// no event up to the latest snapshot. Games are created in a temporary directory renamed
+// into place, and other files are replaced as a whole, so they are either old or new after
+// a crash.
+type FS struct {
+	*registry.FileStore
+
+	mu  sync.Mutex
+	dir string
+	// lastSeq caches Seq of the last event of games whose journal was read.
+	lastSeq map[string]int
+}
+
+// NewFS returns a store keeping its data in dir. dir is created if it doesn't exist.
+// Games left half created by a crash are removed.
+func NewFS(dir string) (*FS, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "games"), 0755); err != nil {
+		return nil, err
+	}
+
+	leftovers, err := filepath.Glob(filepath.Join(dir, "games", newGamePrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range leftovers {
+		if err := os.RemoveAll(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return &FS{
+		FileStore: registry.NewFileStore(filepath.Join(dir, "players.json")),
+		dir:       dir,
+		lastSeq:   make(map[string]int, 0),
+	}, nil
+}
+
+func (s *FS) gameDir(id string) string {
+	return filepath.Join(s.dir, "games", id)
+}
+
+// newGamePrefix prefixes directories of games being created, which are not valid game ids.
+const newGamePrefix = ".new-"
+
+// CreateGame adds a game with no events.
+func (s *FS) CreateGame(g Game) error {
+	if !validGameID.MatchString(g.ID) {
+		return fmt.Errorf("invalid game id %q", g.ID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := s.gameDir(g.ID)
+
+	switch _, err := os.Stat(filepath.Join(dir, "game.json")); {
+	case err == nil:
+		return fmt.Errorf("game %q: %w", g.ID, ErrExists)
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	// a directory without game.json is left by a crash of an older version creating the game.
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempDir(filepath.Dir(dir), newGamePrefix+g.ID+"-")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmp)
+
+	if err := writeJSON(filepath.Join(tmp, "game.json"), g); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, dir); err != nil {
+		return err
+	}
+
+	if err := syncDir(filepath.Dir(dir)); err != nil {
+		return err
+	}
+
+	s.lastSeq[g.ID] = 0
+
+	return nil
+}
+
+// GetGame returns ErrNotFound if there is no game with id.
+func (s *FS) GetGame(id string) (Game, error) {
+	var g Game
+
+	if !validGameID.MatchString(id) {
+		return g, fmt.Errorf("game %q: %w", id, ErrNotFound)
+	}
+
+	err := readJSON(filepath.Join(s.gameDir(id), "game.json"), &g)
+	if os.IsNotExist(err) {
+		return g, fmt.Errorf("game %q: %w", id, ErrNotFound)
+	}
+
+	return g, err
+}
+
+// ListGames returns games matching q, oldest first.
+func (s *FS) ListGames(q Query) ([]Game, error) {
+	ids, err := s.gameIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var games []Game
+
+	for _, id := range ids {
+		g, err := s.GetGame(id)
+		if errors.Is(err, ErrNotFound) {
+			// left by a crash of an older version creating the game.
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if q.matches(g.Players, g.StartedAt) {
+			games = append(games, g)
+		}
+	}
+
+	sortGames(games)
+
+	return games, nil
+}
+
+// AppendEvents adds events to the end of the journal of the game.
+func (s *FS) AppendEvents(gameID string, events ...Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lastSeq, err := s.loadLastSeq(gameID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkSeq(gameID, lastSeq, events); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.gameDir(gameID), "journal.jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		// the journal may now end with a torn line, so it has to be read again.
+		delete(s.lastSeq, gameID)
+
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		delete(s.lastSeq, gameID)
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	s.lastSeq[gameID] = lastSeq + len(events)
+
+	return nil
+}
+
+// Events returns events of the game with Seq greater than afterSeq.
+func (s *FS) Events(gameID string, afterSeq int) ([]Event, error) {
+	if _, err := s.GetGame(gameID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, _, err := s.readJournal(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	var after []Event
+
+	for _, e := range events {
+		if e.Seq > afterSeq {
+			after = append(after, e)
+		}
+	}
+
+	return after, nil
+}
+
+// loadLastSeq returns Seq of the last event of the game. A torn line at the end of the
+// journal is cut off, so new events are appended after the last complete one.
+func (s *FS) loadLastSeq(gameID string) (int, error) {
+	if seq, ok := s.lastSeq[gameID]; ok {
+		return seq, nil
+	}
+
+	if _, err := s.GetGame(gameID); err != nil {
+		return 0, err
+	}
+
+	events, validSize, err := s.readJournal(gameID)
+	if err != nil {
+		return 0, err
+	}
+
+	path := filepath.Join(s.gameDir(gameID), "journal.jsonl")
+	if info, err := os.Stat(path); err == nil && info.Size() > validSize {
+		if err := os.Truncate(path, validSize); err != nil {
+			return 0, err
+		}
+	}
+
+	var snapshot Snapshot
+	if err := readJSON(filepath.Join(s.gameDir(gameID), "snapshot.json"), &snapshot); err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	// the journal has no events up to the snapshot once it is compacted.
+	seq := snapshot.Seq
+	if len(events) > 0 && events[len(events)-1].Seq > seq {
+		seq = events[len(events)-1].Seq
+	}
+
+	s.lastSeq[gameID] = seq
+
+	return seq, nil
+}
+
+// readJournal returns events of the journal and size of the journal up to the end of
+// the last complete line.
+func (s *FS) readJournal(gameID string) ([]Event, int64, error) {
+	f, err := os.Open(filepath.Join(s.gameDir(gameID), "journal.jsonl"))
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer f.Close()
+
+	var events []Event
+	var size int64
+
+	r := bufio.NewReader(f)
+
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) == 0 || line[len(line)-1] != '\n' {
+			// end of journal, or a line torn by a crash while appending.
+			return events, size, nil
+		}
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var e Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, 0, fmt.Errorf("game %q: corrupt journal at offset %d: %v", gameID, size, err)
+		}
+
+		events = append(events, e)
+		size += int64(len(line))
+	}
+}
+
+// SaveSnapshot replaces the snapshot of the game, and then compacts the journal to the
+// events after it.
+func (s *FS) SaveSnapshot(gameID string, snapshot Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lastSeq, err := s.loadLastSeq(gameID)
+	if err != nil {
+		return err
+	}
+
+	if snapshot.Seq > lastSeq {
+		return fmt.Errorf("game %q: snapshot after event %d which is not logged", gameID, snapshot.Seq)
+	}
+
+	if err := writeJSON(filepath.Join(s.gameDir(gameID), "snapshot.json"), snapshot); err != nil {
+		return err
+	}
+
+	return s.compact(gameID, snapshot.Seq)
+}
+
+// compact replaces the journal of the game with its events after seq. The snapshot after seq
+// must be saved before, as a crash while compacting leaves either journal.
+func (s *FS) compact(gameID string, seq int) error {
+	events, _, err := s.readJournal(gameID)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	for _, e := range events {
+		if e.Seq <= seq {
+			continue
+		}
+
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	return writeFile(filepath.Join(s.gameDir(gameID), "journal.jsonl"), buf.Bytes())
+}
+
+// LatestSnapshot returns false if no snapshot is saved for the game.
+func (s *FS) LatestSnapshot(gameID string) (Snapshot, bool, error) {
+	var snapshot Snapshot
+
+	if _, err := s.GetGame(gameID); err != nil {
+		return snapshot, false, err
+	}
+
+	err := readJSON(filepath.Join(s.gameDir(gameID), "snapshot.json"), &snapshot)
+	if os.IsNotExist(err) {
+		return snapshot, false, nil
+	}
+
+	return snapshot, err == nil, err
+}
+
+// SaveResult adds or replaces result of a game.
+func (s *FS) SaveResult(r Result) error {
+	if _, err := s.GetGame(r.GameID); err != nil {
+		return err
+	}
+
+	return writeJSON(filepath.Join(s.gameDir(r.GameID), "result.json"), r)
+}
+
+// GetResult returns ErrNotFound if game has no result.
+func (s *FS) GetResult(gameID string) (Result, error) {
+	var r Result
+
+	if _, err := s.GetGame(gameID); err != nil {
+		return r, err
+	}
+
+	err := readJSON(filepath.Join(s.gameDir(gameID), "result.json"), &r)
+	if os.IsNotExist(err) {
+		return r, fmt.Errorf("result of game %q: %w", gameID, ErrNotFound)
+	}
+
+	return r, err
+}
+
+// ListResults returns results matching q, oldest first.
+func (s *FS) ListResults(q Query) ([]Result, error) {
+	ids, err := s.gameIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+
+	for _, id := range ids {
+		var r Result
+
+		err := readJSON(filepath.Join(s.gameDir(id), "result.json"), &r)
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if q.matches(r.Players, r.FinishedAt) {
+			results = append(results, r)
+		}
+	}
+
+	sortResults(results)
+
+	return results, nil
+}
+
+func (s *FS) gameIDs() ([]string, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(s.dir, "games"))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(entries))
+
+	for _, e := range entries {
+		if e.IsDir() && validGameID.MatchString(e.Name()) {
+			ids = append(ids, e.Name())
+		}
+	}
+
+	return ids, nil
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// writeJSON replaces file at path with v, see writeFile.
+func writeJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(path, data)
+}
+
+// writeFile replaces file at path with data, through a synced temporary file renamed over it.
+// The directory is synced after, so the rename is durable.
+func writeFile(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir syncs entries of directory dir to disk.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Sync(); err != nil {
+		d.Close()
+
+		return err
+	}
+
+	return d.Close()
+}
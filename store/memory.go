@@ -0,0 +1,234 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/RenugaParamalingam/carrom/registry"
+)
+
+// Memory is a Store which keeps everything in memory. It is meant for tests.
+type Memory struct {
+	mu        sync.Mutex
+	profiles  map[string]registry.Profile
+	order     []string
+	games     map[string]Game
+	gameOrder []string
+	events    map[string][]Event
+	snapshots map[string]Snapshot
+	results   map[string]Result
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{
+		profiles:  make(map[string]registry.Profile, 0),
+		games:     make(map[string]Game, 0),
+		events:    make(map[string][]Event, 0),
+		snapshots: make(map[string]Snapshot, 0),
+		results:   make(map[string]Result, 0),
+	}
+}
+
+// PutProfile adds profile or replaces the one with the same ID.
+func (m *Memory) PutProfile(p registry.Profile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.profiles[p.ID]; !ok {
+		m.order = append(m.order, p.ID)
+	}
+
+	m.profiles[p.ID] = p
+
+	return nil
+}
+
+// GetProfile returns registry.ErrNotFound if there is no profile with id.
+func (m *Memory) GetProfile(id string) (registry.Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.profiles[id]
+	if !ok {
+		return registry.Profile{}, registry.ErrNotFound
+	}
+
+	return p, nil
+}
+
+// ListProfiles returns profiles in the order they were added.
+func (m *Memory) ListProfiles() ([]registry.Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profiles := make([]registry.Profile, 0, len(m.order))
+	for _, id := range m.order {
+		profiles = append(profiles, m.profiles[id])
+	}
+
+	return profiles, nil
+}
+
+// CreateGame adds a game with no events.
+func (m *Memory) CreateGame(g Game) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[g.ID]; ok {
+		return fmt.Errorf("game %q: %w", g.ID, ErrExists)
+	}
+
+	g.Players = append([]string(nil), g.Players...)
+	m.games[g.ID] = g
+	m.gameOrder = append(m.gameOrder, g.ID)
+
+	return nil
+}
+
+// GetGame returns ErrNotFound if there is no game with id.
+func (m *Memory) GetGame(id string) (Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.games[id]
+	if !ok {
+		return Game{}, fmt.Errorf("game %q: %w", id, ErrNotFound)
+	}
+
+	return g, nil
+}
+
+// ListGames returns games matching q, oldest first.
+func (m *Memory) ListGames(q Query) ([]Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var games []Game
+
+	for _, id := range m.gameOrder {
+		if g := m.games[id]; q.matches(g.Players, g.StartedAt) {
+			games = append(games, g)
+		}
+	}
+
+	sortGames(games)
+
+	return games, nil
+}
+
+// AppendEvents adds events to the end of log of the game.
+func (m *Memory) AppendEvents(gameID string, events ...Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[gameID]; !ok {
+		return fmt.Errorf("game %q: %w", gameID, ErrNotFound)
+	}
+
+	if err := checkSeq(gameID, len(m.events[gameID]), events); err != nil {
+		return err
+	}
+
+	m.events[gameID] = append(m.events[gameID], events...)
+
+	return nil
+}
+
+// Events returns events of the game with Seq greater than afterSeq.
+func (m *Memory) Events(gameID string, afterSeq int) ([]Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[gameID]; !ok {
+		return nil, fmt.Errorf("game %q: %w", gameID, ErrNotFound)
+	}
+
+	events := m.events[gameID]
+	if afterSeq >= len(events) {
+		return nil, nil
+	}
+
+	if afterSeq < 0 {
+		afterSeq = 0
+	}
+
+	return append([]Event(nil), events[afterSeq:]...), nil
+}
+
+// SaveSnapshot replaces the snapshot of the game.
+func (m *Memory) SaveSnapshot(gameID string, s Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[gameID]; !ok {
+		return fmt.Errorf("game %q: %w", gameID, ErrNotFound)
+	}
+
+	if s.Seq > len(m.events[gameID]) {
+		return fmt.Errorf("game %q: snapshot after event %d which is not logged", gameID, s.Seq)
+	}
+
+	m.snapshots[gameID] = s
+
+	return nil
+}
+
+// LatestSnapshot returns false if no snapshot is saved for the game.
+func (m *Memory) LatestSnapshot(gameID string) (Snapshot, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[gameID]; !ok {
+		return Snapshot{}, false, fmt.Errorf("game %q: %w", gameID, ErrNotFound)
+	}
+
+	s, ok := m.snapshots[gameID]
+
+	return s, ok, nil
+}
+
+// SaveResult adds or replaces result of a game.
+func (m *Memory) SaveResult(r Result) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[r.GameID]; !ok {
+		return fmt.Errorf("game %q: %w", r.GameID, ErrNotFound)
+	}
+
+	m.results[r.GameID] = r
+
+	return nil
+}
+
+// GetResult returns ErrNotFound if game has no result.
+func (m *Memory) GetResult(gameID string) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r, ok := m.results[gameID]
+	if !ok {
+		return Result{}, fmt.Errorf("result of game %q: %w", gameID, ErrNotFound)
+	}
+
+	return r, nil
+}
+
+// ListResults returns results matching q, oldest first.
+func (m *Memory) ListResults(q Query) ([]Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var results []Result
+
+	for _, id := range m.gameOrder {
+		if r, ok := m.results[id]; ok && q.matches(r.Players, r.FinishedAt) {
+			results = append(results, r)
+		}
+	}
+
+	sortResults(results)
+
+	return results, nil
+}
@@ -0,0 +1,162 @@
+// Package store saves games, players and results of carrom games.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+	"github.com/RenugaParamalingam/carrom/registry"
+)
+
+var (
+	// ErrNotFound is returned when there is no game or result with the id asked.
+	ErrNotFound = errors.New("not found")
+	// ErrExists is returned when a game is created with an id already used.
+	ErrExists = errors.New("already exists")
+)
+
+// Game is what is known about a game when it is created.
+// Players are player ids of the registry, or player names if registry is not used.
+type Game struct {
+	ID        string    `json:"id"`
+	Players   []string  `json:"players"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// Event kinds.
+const (
+	// TurnEvent is a turn played by a player.
+	TurnEvent = "turn"
+	// TimeoutEvent is a turn recorded by shot clock.
+	TimeoutEvent = "timeout"
+	// FoulEvent is a foul declared by the umpire.
+	FoulEvent = "foul"
+)
+
+// Event is an entry of the event log of a game. Seq of events of a game starts from 1
+// and has no gaps.
+type Event struct {
+	Seq  int                `json:"seq"`
+	Time time.Time          `json:"time"`
+	Kind string             `json:"kind"`
+	Turn *carrom.TurnResult `json:"turn,omitempty"`
+}
+
+// Snapshot is state of a game after the event Seq. State is stored as given,
+// so it can be restored without replaying the events before it.
+type Snapshot struct {
+	Seq   int             `json:"seq"`
+	Time  time.Time       `json:"time"`
+	State json.RawMessage `json:"state"`
+}
+
+// Result is how a game ended. Winner is empty for a drawn or abandoned game.
+type Result struct {
+	GameID  string         `json:"gameId"`
+	Players []string       `json:"players"`
+	Winner  string         `json:"winner,omitempty"`
+	Points  map[string]int `json:"points"`
+	// Outcome and Reason are the kind and reason of carrom.GameOutcome, eg. "won" and "lead reached",
+	// or "abandoned" for games left before they were over.
+	Outcome string `json:"outcome,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Margin  int    `json:"margin,omitempty"`
+	// Fouls is foul history of the players who fouled.
+	Fouls      map[string][]carrom.Foul `json:"fouls,omitempty"`
+	FinishedAt time.Time                `json:"finishedAt"`
+}
+
+// Query filters games and results. Zero value of a field matches everything.
+// From is inclusive and To is exclusive.
+type Query struct {
+	Player string
+	From   time.Time
+	To     time.Time
+}
+
+// Store keeps games with their event logs and snapshots, players and results.
+type Store interface {
+	registry.Store
+
+	CreateGame(g Game) error
+	GetGame(id string) (Game, error)
+	// ListGames returns games with player and start time matching q, oldest first.
+	ListGames(q Query) ([]Game, error)
+
+	// AppendEvents adds events to the end of log of the game. Seq of the events must
+	// continue from the last event of the game.
+	AppendEvents(gameID string, events ...Event) error
+	// Events returns events of the game with Seq greater than afterSeq. Events up to the
+	// latest snapshot may be compacted away by the store, see Recover.
+	Events(gameID string, afterSeq int) ([]Event, error)
+
+	// SaveSnapshot replaces the snapshot of the game.
+	SaveSnapshot(gameID string, s Snapshot) error
+	// LatestSnapshot returns false if no snapshot is saved for the game.
+	LatestSnapshot(gameID string) (Snapshot, bool, error)
+
+	SaveResult(r Result) error
+	GetResult(gameID string) (Result, error)
+	// ListResults returns results with player and finish time matching q, oldest first.
+	ListResults(q Query) ([]Result, error)
+}
+
+// Recover returns the latest snapshot of a game and the events logged after it.
+// State of the game is the snapshot with the events applied in order.
+func Recover(s Store, gameID string) (Snapshot, []Event, error) {
+	snapshot, _, err := s.LatestSnapshot(gameID)
+	if err != nil {
+		return Snapshot{}, nil, err
+	}
+
+	events, err := s.Events(gameID, snapshot.Seq)
+	if err != nil {
+		return Snapshot{}, nil, err
+	}
+
+	return snapshot, events, nil
+}
+
+func (q Query) matches(players []string, at time.Time) bool {
+	if !q.From.IsZero() && at.Before(q.From) {
+		return false
+	}
+
+	if !q.To.IsZero() && !at.Before(q.To) {
+		return false
+	}
+
+	if q.Player == "" {
+		return true
+	}
+
+	for _, p := range players {
+		if p == q.Player {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkSeq(gameID string, lastSeq int, events []Event) error {
+	for i, e := range events {
+		if e.Seq != lastSeq+i+1 {
+			return fmt.Errorf("game %q: event seq %d doesn't follow %d", gameID, e.Seq, lastSeq+i)
+		}
+	}
+
+	return nil
+}
+
+func sortGames(games []Game) {
+	sort.SliceStable(games, func(i, j int) bool { return games[i].StartedAt.Before(games[j].StartedAt) })
+}
+
+func sortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool { return results[i].FinishedAt.Before(results[j].FinishedAt) })
+}
@@ -0,0 +1,264 @@
+package store_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RenugaParamalingam/carrom/carrom"
+	"github.com/RenugaParamalingam/carrom/registry"
+	"github.com/RenugaParamalingam/carrom/store"
+)
+
+var day = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func turnEvent(seq int, player string) store.Event {
+	return store.Event{
+		Seq:  seq,
+		Time: day.Add(time.Duration(seq) * time.Minute),
+		Kind: store.TurnEvent,
+		Turn: &carrom.TurnResult{Turn: seq, PlayerName: player, Input: carrom.Input{StrikeCode: 5}},
+	}
+}
+
+func testStore(t *testing.T, s store.Store) {
+	games := []store.Game{
+		{ID: "g1", Players: []string{"p1", "p2"}, StartedAt: day},
+		{ID: "g2", Players: []string{"p2", "p3"}, StartedAt: day.Add(24 * time.Hour)},
+		{ID: "g3", Players: []string{"p1", "p3"}, StartedAt: day.Add(48 * time.Hour)},
+	}
+
+	for _, g := range games {
+		if err := s.CreateGame(g); err != nil {
+			t.Fatalf("CreateGame(%s)= %v, want= nil", g.ID, err)
+		}
+	}
+
+	if err := s.CreateGame(games[0]); !errors.Is(err, store.ErrExists) {
+		t.Errorf("CreateGame(g1) again= %v, want= %v", err, store.ErrExists)
+	}
+
+	if err := s.AppendEvents("g1", turnEvent(1, "p1"), turnEvent(2, "p2")); err != nil {
+		t.Fatalf("AppendEvents()= %v, want= nil", err)
+	}
+
+	if err := s.AppendEvents("g1", turnEvent(4, "p2")); err == nil {
+		t.Errorf("AppendEvents() with gap in seq= nil, want= error")
+	}
+
+	if err := s.AppendEvents("g4", turnEvent(1, "p1")); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("AppendEvents() to unknown game= %v, want= %v", err, store.ErrNotFound)
+	}
+
+	if err := s.SaveSnapshot("g1", store.Snapshot{Seq: 2, State: json.RawMessage(`{"turn":2}`)}); err != nil {
+		t.Fatalf("SaveSnapshot()= %v, want= nil", err)
+	}
+
+	if err := s.SaveSnapshot("g1", store.Snapshot{Seq: 5}); err == nil {
+		t.Errorf("SaveSnapshot() after unlogged event= nil, want= error")
+	}
+
+	if err := s.AppendEvents("g1", turnEvent(3, "p1")); err != nil {
+		t.Fatalf("AppendEvents()= %v, want= nil", err)
+	}
+
+	snapshot, events, err := store.Recover(s, "g1")
+	if err != nil || snapshot.Seq != 2 || string(snapshot.State) != `{"turn":2}` ||
+		len(events) != 1 || events[0].Seq != 3 || events[0].Turn.PlayerName != "p1" {
+		t.Errorf("Recover(g1)= %+v, %+v, err: %v, want= snapshot at 2 and event 3", snapshot, events, err)
+	}
+
+	if _, ok, err := s.LatestSnapshot("g2"); ok || err != nil {
+		t.Errorf("LatestSnapshot(g2)= %t, %v, want= false, nil", ok, err)
+	}
+
+	for _, g := range games[:2] {
+		r := store.Result{GameID: g.ID, Players: g.Players, Winner: g.Players[0], FinishedAt: g.StartedAt.Add(time.Hour)}
+		if err := s.SaveResult(r); err != nil {
+			t.Fatalf("SaveResult(%s)= %v, want= nil", g.ID, err)
+		}
+	}
+
+	if _, err := s.GetResult("g3"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetResult(g3)= %v, want= %v", err, store.ErrNotFound)
+	}
+
+	gameQueries := []struct {
+		query    store.Query
+		expected []string
+	}{
+		{store.Query{}, []string{"g1", "g2", "g3"}},
+		{store.Query{Player: "p1"}, []string{"g1", "g3"}},
+		{store.Query{Player: "p3", From: day.Add(time.Hour)}, []string{"g2", "g3"}},
+		{store.Query{From: day, To: day.Add(48 * time.Hour)}, []string{"g1", "g2"}},
+	}
+
+	for _, q := range gameQueries {
+		actual, err := s.ListGames(q.query)
+		if err != nil || len(actual) != len(q.expected) {
+			t.Errorf("ListGames(%+v)= %+v, err: %v, want= %v", q.query, actual, err, q.expected)
+
+			continue
+		}
+
+		for i, g := range actual {
+			if g.ID != q.expected[i] {
+				t.Errorf("ListGames(%+v)= %+v, want= %v", q.query, actual, q.expected)
+			}
+		}
+	}
+
+	if results, err := s.ListResults(store.Query{Player: "p3"}); err != nil || len(results) != 1 || results[0].GameID != "g2" {
+		t.Errorf("ListResults(p3)= %+v, err: %v, want= [g2]", results, err)
+	}
+
+	r, err := registry.New(s)
+	if err != nil {
+		t.Fatalf("registry.New()= %v, want= nil", err)
+	}
+
+	if _, err := r.Register(registry.Profile{DisplayName: "p1"}); err != nil {
+		t.Errorf("Register()= %v, want= nil", err)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, store.NewMemory())
+}
+
+func TestFS(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := store.NewFS(dir)
+	if err != nil {
+		t.Fatalf("NewFS()= %v, want= nil", err)
+	}
+
+	testStore(t, s)
+
+	reopened, err := store.NewFS(dir)
+	if err != nil {
+		t.Fatalf("NewFS()= %v, want= nil", err)
+	}
+
+	// events up to the snapshot at 2 are compacted away.
+	if events, err := reopened.Events("g1", 0); err != nil || len(events) != 1 || events[0].Seq != 3 {
+		t.Errorf("Events(g1) after reopening= %+v, err: %v, want= event 3", events, err)
+	}
+
+	if profiles, err := reopened.ListProfiles(); err != nil || len(profiles) != 1 {
+		t.Errorf("ListProfiles() after reopening= %+v, err: %v, want= 1 profile", profiles, err)
+	}
+}
+
+func TestFSTornJournal(t *testing.T) {
+	dir := t.TempDir()
+	s, _ := store.NewFS(dir)
+
+	if err := s.CreateGame(store.Game{ID: "g1", Players: []string{"p1", "p2"}}); err != nil {
+		t.Fatalf("CreateGame()= %v, want= nil", err)
+	}
+
+	if err := s.AppendEvents("g1", turnEvent(1, "p1")); err != nil {
+		t.Fatalf("AppendEvents()= %v, want= nil", err)
+	}
+
+	// a crash while appending the second event leaves half of its line.
+	journal := filepath.Join(dir, "games", "g1", "journal.jsonl")
+	f, _ := os.OpenFile(journal, os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString(`{"seq":2,"kind":"tu`)
+	f.Close()
+
+	reopened, _ := store.NewFS(dir)
+
+	if events, err := reopened.Events("g1", 0); err != nil || len(events) != 1 {
+		t.Errorf("Events() of torn journal= %+v, err: %v, want= 1 event", events, err)
+	}
+
+	if err := reopened.AppendEvents("g1", turnEvent(2, "p2")); err != nil {
+		t.Fatalf("AppendEvents() after torn line= %v, want= nil", err)
+	}
+
+	if events, err := reopened.Events("g1", 1); err != nil || len(events) != 1 || events[0].Turn.PlayerName != "p2" {
+		t.Errorf("Events(g1, 1)= %+v, err: %v, want= event 2 of p2", events, err)
+	}
+}
+
+func TestFSCompactsJournal(t *testing.T) {
+	dir := t.TempDir()
+	s, _ := store.NewFS(dir)
+
+	if err := s.CreateGame(store.Game{ID: "g1", Players: []string{"p1", "p2"}}); err != nil {
+		t.Fatalf("CreateGame()= %v, want= nil", err)
+	}
+
+	for seq := 1; seq <= 10; seq++ {
+		if err := s.AppendEvents("g1", turnEvent(seq, "p1")); err != nil {
+			t.Fatalf("AppendEvents(%d)= %v, want= nil", seq, err)
+		}
+	}
+
+	journal := filepath.Join(dir, "games", "g1", "journal.jsonl")
+	before, _ := os.Stat(journal)
+
+	if err := s.SaveSnapshot("g1", store.Snapshot{Seq: 8, State: json.RawMessage(`{"turn":8}`)}); err != nil {
+		t.Fatalf("SaveSnapshot()= %v, want= nil", err)
+	}
+
+	if after, _ := os.Stat(journal); after.Size() >= before.Size() {
+		t.Errorf("journal after snapshot= %d bytes, want= less than %d", after.Size(), before.Size())
+	}
+
+	if err := s.SaveSnapshot("g1", store.Snapshot{Seq: 10, State: json.RawMessage(`{"turn":10}`)}); err != nil {
+		t.Fatalf("SaveSnapshot()= %v, want= nil", err)
+	}
+
+	// Seq of the next event follows the snapshot, even with no event left in the journal.
+	reopened, _ := store.NewFS(dir)
+
+	if err := reopened.AppendEvents("g1", turnEvent(11, "p2")); err != nil {
+		t.Fatalf("AppendEvents(11) after compacting all events= %v, want= nil", err)
+	}
+
+	snapshot, events, err := store.Recover(reopened, "g1")
+	if err != nil || snapshot.Seq != 10 || len(events) != 1 || events[0].Seq != 11 || events[0].Turn.PlayerName != "p2" {
+		t.Errorf("Recover(g1)= %+v, %+v, err: %v, want= snapshot at 10 and event 11", snapshot, events, err)
+	}
+}
+
+func TestFSHalfCreatedGame(t *testing.T) {
+	dir := t.TempDir()
+	s, _ := store.NewFS(dir)
+
+	if err := s.CreateGame(store.Game{ID: "g1", Players: []string{"p1", "p2"}}); err != nil {
+		t.Fatalf("CreateGame()= %v, want= nil", err)
+	}
+
+	// crashes while creating games leave a directory without game.json.
+	for _, d := range []string{"g2", ".new-g3-123"} {
+		if err := os.Mkdir(filepath.Join(dir, "games", d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reopened, _ := store.NewFS(dir)
+
+	if games, err := reopened.ListGames(store.Query{}); err != nil || len(games) != 1 || games[0].ID != "g1" {
+		t.Errorf("ListGames()= %+v, err: %v, want= [g1]", games, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "games", ".new-g3-123")); !os.IsNotExist(err) {
+		t.Errorf("game left half created by a crash is not removed: %v", err)
+	}
+
+	if err := reopened.CreateGame(store.Game{ID: "g2", Players: []string{"p1", "p2"}}); err != nil {
+		t.Errorf("CreateGame(g2) over half created game= %v, want= nil", err)
+	}
+
+	if g, err := reopened.GetGame("g2"); err != nil || g.ID != "g2" {
+		t.Errorf("GetGame(g2)= %+v, err: %v, want= g2", g, err)
+	}
+}